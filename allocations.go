@@ -0,0 +1,244 @@
+package chargify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// ProrationScheme controls how a change in a component allocation is charged or credited
+type ProrationScheme string
+
+var (
+	// ProrationSchemeNoProrate makes no charge or credit for the change
+	ProrationSchemeNoProrate ProrationScheme = "no-prorate"
+	// ProrationSchemeProrateAttemptCapture charges the prorated amount of an upgrade and attempts to collect it immediately
+	ProrationSchemeProrateAttemptCapture ProrationScheme = "prorate-attempt-capture"
+	// ProrationSchemeProrateDelayCapture charges the prorated amount of an upgrade and collects it at the next renewal
+	ProrationSchemeProrateDelayCapture ProrationScheme = "prorate-delay-capture"
+	// ProrationSchemeFullPriceAttemptCapture charges the full price of an upgrade and attempts to collect it immediately
+	ProrationSchemeFullPriceAttemptCapture ProrationScheme = "full-price-attempt-capture"
+	// ProrationSchemeFullPriceDelayCapture charges the full price of an upgrade and collects it at the next renewal
+	ProrationSchemeFullPriceDelayCapture ProrationScheme = "full-price-delay-capture"
+	// ProrationSchemeProrate credits the prorated amount of a downgrade
+	ProrationSchemeProrate ProrationScheme = "prorate"
+)
+
+// AllocationInput is a change to the allocated quantity of a component on a subscription. On/off components use a quantity
+// of 1 to enable the component and 0 to disable it.
+type AllocationInput struct {
+	ComponentID              int64           `json:"component_id"`
+	Quantity                 int64           `json:"quantity"`                             // The new quantity
+	Memo                     string          `json:"memo,omitempty"`                       // (Optional) A note about the change
+	ProrationUpgradeScheme   ProrationScheme `json:"proration_upgrade_scheme,omitempty"`   // (Optional) How to charge for an increase, instead of the component default
+	ProrationDowngradeScheme ProrationScheme `json:"proration_downgrade_scheme,omitempty"` // (Optional) How to credit a decrease, instead of the component default
+	AccrueCharge             *bool           `json:"accrue_charge,omitempty"`              // (Optional) Add the charge to the balance instead of collecting it now
+	UpgradeCharge            ProrationCharge `json:"upgrade_charge,omitempty"`             // (Optional) How to charge for an increase, for sites using the newer proration settings
+	DowngradeCredit          ProrationCharge `json:"downgrade_credit,omitempty"`           // (Optional) How to credit a decrease, for sites using the newer proration settings
+	PricePointID             *int64          `json:"price_point_id,omitempty"`             // (Optional) The component price point to use instead of the current one
+}
+
+// Allocation is a change to the allocated quantity of a component on a subscription
+type Allocation struct {
+	AllocationID             int64           `json:"allocation_id" mapstructure:"allocation_id"`
+	ComponentID              int64           `json:"component_id" mapstructure:"component_id"`
+	SubscriptionID           int64           `json:"subscription_id" mapstructure:"subscription_id"`
+	Quantity                 int64           `json:"quantity" mapstructure:"quantity"`                   // The quantity after the change
+	PreviousQuantity         int64           `json:"previous_quantity" mapstructure:"previous_quantity"` // The quantity before the change
+	Memo                     string          `json:"memo" mapstructure:"memo"`
+	Timestamp                string          `json:"timestamp" mapstructure:"timestamp"` // When the change was made
+	ProrationUpgradeScheme   ProrationScheme `json:"proration_upgrade_scheme" mapstructure:"proration_upgrade_scheme"`
+	ProrationDowngradeScheme ProrationScheme `json:"proration_downgrade_scheme" mapstructure:"proration_downgrade_scheme"`
+	AccrueCharge             bool            `json:"accrue_charge" mapstructure:"accrue_charge"`
+	UpgradeCharge            ProrationCharge `json:"upgrade_charge" mapstructure:"upgrade_charge"`
+	DowngradeCredit          ProrationCharge `json:"downgrade_credit" mapstructure:"downgrade_credit"`
+	PricePointID             int64           `json:"price_point_id" mapstructure:"price_point_id"`
+	PreviousPricePointID     int64           `json:"previous_price_point_id" mapstructure:"previous_price_point_id"`
+	CreatedAt                string          `json:"created_at" mapstructure:"created_at"`
+}
+
+// AllocationPreview is the preview of the charges and credits of changing component allocations. All amounts are in integer cents.
+type AllocationPreview struct {
+	StartDate              string            `json:"start_date" mapstructure:"start_date"`                               // The start of the period the changes are charged for
+	EndDate                string            `json:"end_date" mapstructure:"end_date"`                                   // The end of the period the changes are charged for
+	PeriodType             string            `json:"period_type" mapstructure:"period_type"`                             // The type of the period
+	Direction              string            `json:"direction" mapstructure:"direction"`                                 // Either upgrade or downgrade
+	ProrationScheme        ProrationScheme   `json:"proration_scheme" mapstructure:"proration_scheme"`                   // The proration scheme that will be used
+	AccrueCharge           bool              `json:"accrue_charge" mapstructure:"accrue_charge"`                         // Whether the charge will be added to the balance instead of collected
+	SubtotalInCents        int64             `json:"subtotal_in_cents" mapstructure:"subtotal_in_cents"`                 // The total before discounts and taxes
+	TotalTaxInCents        int64             `json:"total_tax_in_cents" mapstructure:"total_tax_in_cents"`               // The total of the taxes
+	TotalDiscountInCents   int64             `json:"total_discount_in_cents" mapstructure:"total_discount_in_cents"`     // The total of the discounts
+	TotalInCents           int64             `json:"total_in_cents" mapstructure:"total_in_cents"`                       // The total of the changes
+	ExistingBalanceInCents int64             `json:"existing_balance_in_cents" mapstructure:"existing_balance_in_cents"` // The balance already on the subscription
+	LineItems              []PreviewLineItem `json:"line_items" mapstructure:"line_items"`
+	Allocations            []Allocation      `json:"allocations" mapstructure:"allocations"` // The allocations that would be made
+}
+
+// AllocateComponent changes the allocated quantity of a single component on a subscription
+func AllocateComponent(subscriptionID int64, input *AllocationInput) (*Allocation, error) {
+	if input == nil || input.ComponentID == 0 {
+		return nil, errors.New("component id is required")
+	}
+	if input.Quantity < 0 {
+		return nil, errors.New("quantity cannot be negative")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionAllocationCreate],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+			"componentID":    fmt.Sprintf("%d", input.ComponentID),
+		},
+		Body: map[string]AllocationInput{
+			"allocation": *input,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	allocation := &Allocation{}
+	err = mapstructure.Decode(apiBody["allocation"], allocation)
+	return allocation, err
+}
+
+// AllocateOnOffComponent enables or disables an on/off component on a subscription
+func AllocateOnOffComponent(subscriptionID int64, componentID int64, enabled bool, memo string) (*Allocation, error) {
+	input := &AllocationInput{
+		ComponentID: componentID,
+		Memo:        memo,
+	}
+	if enabled {
+		input.Quantity = 1
+	}
+	return AllocateComponent(subscriptionID, input)
+}
+
+// AllocateComponents changes the allocated quantities of several components on a subscription at once, so that they are
+// charged or credited together
+func AllocateComponents(subscriptionID int64, inputs []AllocationInput) ([]Allocation, error) {
+	body, err := allocationsBody(inputs)
+	if err != nil {
+		return nil, err
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionAllocationsBulk],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: body,
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAllocations(ret)
+}
+
+// PreviewAllocations previews the charges and credits of changing the allocated quantities of components on a subscription,
+// without making any changes
+func PreviewAllocations(subscriptionID int64, inputs []AllocationInput) (*AllocationPreview, error) {
+	body, err := allocationsBody(inputs)
+	if err != nil {
+		return nil, err
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionAllocationPreview],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: body,
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	// the allocations in the preview may or may not be wrapped in an allocation key, so they are decoded separately
+	raw, rawOK := apiBody["allocation_preview"].(map[string]interface{})
+	if !rawOK {
+		return nil, errors.New("could not understand server response")
+	}
+	wrapped, _ := raw["allocations"].([]interface{})
+	delete(raw, "allocations")
+	preview := &AllocationPreview{}
+	err = mapstructure.Decode(raw, preview)
+	if err != nil {
+		return nil, err
+	}
+	preview.Allocations = unwrapAllocations(wrapped)
+	return preview, nil
+}
+
+// ListAllocations lists the historic allocations of a component on a subscription, newest first
+func ListAllocations(subscriptionID int64, componentID int64, page int) ([]Allocation, error) {
+	if page < 1 {
+		return nil, errors.New("page must be 1 or higher, not 0 indexed")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionAllocationsList],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+			"componentID":    fmt.Sprintf("%d", componentID),
+		},
+		QueryParams: &map[string]string{
+			"page": fmt.Sprintf("%d", page),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAllocations(ret)
+}
+
+// allocationsBody builds the body shared by the bulk allocation and preview endpoints
+func allocationsBody(inputs []AllocationInput) (map[string][]AllocationInput, error) {
+	if len(inputs) == 0 {
+		return nil, errors.New("at least one allocation is required")
+	}
+	for i := range inputs {
+		if inputs[i].ComponentID == 0 {
+			return nil, errors.New("component id is required for every allocation")
+		}
+		if inputs[i].Quantity < 0 {
+			return nil, errors.New("quantity cannot be negative")
+		}
+	}
+	return map[string][]AllocationInput{
+		"allocations": inputs,
+	}, nil
+}
+
+// decodeAllocations decodes a response that is an array of objects with an allocation key
+func decodeAllocations(ret APIReturn) ([]Allocation, error) {
+	apiBody, bodyOK := ret.Body.([]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	return unwrapAllocations(apiBody), nil
+}
+
+// unwrapAllocations decodes each allocation, whether or not it is wrapped in an allocation key
+func unwrapAllocations(raw []interface{}) []Allocation {
+	data := []Allocation{}
+	for i := range raw {
+		if entry, entryOK := raw[i].(map[string]interface{}); entryOK {
+			if wrapped, wrappedOK := entry["allocation"]; wrappedOK {
+				entry, _ = wrapped.(map[string]interface{})
+			}
+			allocation := Allocation{}
+			if err := mapstructure.Decode(entry, &allocation); err == nil {
+				data = append(data, allocation)
+			}
+		}
+	}
+	return data
+}
@@ -0,0 +1,44 @@
+package chargify
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocationInput(t *testing.T) {
+	// a quantity of 0 must still be sent to turn off a component
+	encoded, err := json.Marshal(&AllocationInput{ComponentID: 3})
+	require.Nil(t, err)
+	assert.Equal(t, `{"component_id":3,"quantity":0}`, string(encoded))
+
+	_, err = allocationsBody(nil)
+	assert.NotNil(t, err)
+	_, err = allocationsBody([]AllocationInput{{Quantity: 1}})
+	assert.NotNil(t, err)
+	_, err = allocationsBody([]AllocationInput{{ComponentID: 1, Quantity: -1}})
+	assert.NotNil(t, err)
+	body, err := allocationsBody([]AllocationInput{{ComponentID: 1, Quantity: 2, ProrationUpgradeScheme: ProrationSchemeProrateDelayCapture}})
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(body["allocations"]))
+
+	_, err = AllocateComponent(1, &AllocationInput{})
+	assert.NotNil(t, err)
+}
+
+func TestUnwrapAllocations(t *testing.T) {
+	raw := []interface{}{}
+	err := json.Unmarshal([]byte(`[
+		{"allocation": {"allocation_id": 1, "component_id": 2, "quantity": 5, "previous_quantity": 3}},
+		{"allocation_id": 2, "component_id": 2, "quantity": 4, "previous_quantity": 5},
+		"not an allocation"
+	]`), &raw)
+	require.Nil(t, err)
+	allocations := unwrapAllocations(raw)
+	require.Equal(t, 2, len(allocations))
+	assert.Equal(t, int64(5), allocations[0].Quantity)
+	assert.Equal(t, int64(3), allocations[0].PreviousQuantity)
+	assert.Equal(t, int64(2), allocations[1].AllocationID)
+}
@@ -0,0 +1,331 @@
+package chargify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// BulkOperation is an operation run against a single subscription by RunBulkOperation
+type BulkOperation func(subscriptionID int64) error
+
+// BulkMigrate migrates each subscription with the same options
+func BulkMigrate(migrationOptions *MigrationOptions) BulkOperation {
+	return func(subscriptionID int64) error {
+		_, err := MigrateSubscriptionWithOptions(subscriptionID, migrationOptions)
+		return err
+	}
+}
+
+// BulkChangeProduct changes the product of each subscription with the same options
+func BulkChangeProduct(changeOptions *ChangeSubscriptionProductOptions) BulkOperation {
+	return func(subscriptionID int64) error {
+		_, err := ChangeSubscriptionProduct(subscriptionID, changeOptions)
+		return err
+	}
+}
+
+// BulkCancel cancels each subscription with the same options
+func BulkCancel(cancelOptions *CancelSubscriptionOptions) BulkOperation {
+	return func(subscriptionID int64) error {
+		return CancelSubscriptionWithOptions(subscriptionID, cancelOptions)
+	}
+}
+
+// BulkAddCoupons applies the same coupons to each subscription
+func BulkAddCoupons(codes ...string) BulkOperation {
+	return func(subscriptionID int64) error {
+		_, err := AddCouponsToSubscription(subscriptionID, codes...)
+		return err
+	}
+}
+
+// BulkAllocateComponent makes the same component allocation on each subscription
+func BulkAllocateComponent(input *AllocationInput) BulkOperation {
+	return func(subscriptionID int64) error {
+		_, err := AllocateComponent(subscriptionID, input)
+		return err
+	}
+}
+
+// BulkRunnerOptions configures RunBulkOperation. Zero values use the defaults.
+type BulkRunnerOptions struct {
+	Concurrency       int     // How many operations run at once. Defaults to 4.
+	RequestsPerSecond float64 // The most operations started per second, to stay under the Chargify rate limit. Defaults to 5.
+	// JournalPath is the file each result is appended to, one JSON object per line. When the file already exists, the
+	// subscriptions that succeeded in it are skipped, so a job can be resumed by running it again with the same path.
+	// If it is empty, no journal is kept.
+	JournalPath string
+	// OperationID names the job in the journal, such as "reprice-2026-10", and is required with a JournalPath. A journal
+	// belongs to one operation: resuming with a journal written under a different OperationID returns an error rather than
+	// skipping subscriptions that only succeeded for the other operation.
+	OperationID string
+	// RerunUnfinished runs the operation again on subscriptions the journal shows were started but never finished, such as
+	// when the process crashed mid-operation. By default they are reported in BulkSummary.Unfinished instead, since the
+	// operation may already have been applied and running it again could apply it twice.
+	RerunUnfinished bool
+}
+
+// BulkResult is the result of running an operation against a single subscription. In the journal, a result with Started
+// set is written before the operation runs, and is followed by the finished result once it returns.
+type BulkResult struct {
+	OperationID    string `json:"operation_id,omitempty"`
+	SubscriptionID int64  `json:"subscription_id"`
+	Started        bool   `json:"started,omitempty"`
+	Success        bool   `json:"success"`
+	Error          string `json:"error,omitempty"`
+	StartedAt      string `json:"started_at,omitempty"`
+	CompletedAt    string `json:"completed_at,omitempty"`
+}
+
+// BulkSummary is the result of a bulk job
+type BulkSummary struct {
+	Succeeded  int          // The number of subscriptions the operation succeeded on in this run
+	Failed     int          // The number of subscriptions the operation failed on in this run
+	Skipped    int          // The number of subscriptions skipped because they already succeeded in the journal, or were duplicated
+	Unfinished []int64      // The subscriptions that were started in an earlier run but never finished, and were not run again
+	Results    []BulkResult // The results of this run, in the order they completed
+}
+
+// RunBulkOperation runs the operation against each subscription with bounded concurrency and a rate limit, journaling each
+// result. A failure on one subscription does not stop the others; check the summary for failures. An error is only returned
+// when the journal cannot be read or written, or when the context is canceled, in which case the summary covers the
+// operations that finished. If the journal cannot be written, no more operations are started, since their results could
+// not be recorded; the operations already running finish and are in the summary, but not in the journal.
+//
+// Each subscription is marked as started in the journal before the operation runs. If the process crashes while an
+// operation is running, the next run cannot know whether it was applied, so the subscription is listed in
+// BulkSummary.Unfinished rather than run again. Check those subscriptions by hand, or set RerunUnfinished when the
+// operation is safe to repeat.
+func RunBulkOperation(ctx context.Context, subscriptionIDs []int64, operation BulkOperation, runnerOptions *BulkRunnerOptions) (*BulkSummary, error) {
+	if operation == nil {
+		return nil, errors.New("operation is required")
+	}
+	options := BulkRunnerOptions{}
+	if runnerOptions != nil {
+		options = *runnerOptions
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = 4
+	}
+	if options.RequestsPerSecond <= 0 {
+		options.RequestsPerSecond = 5
+	}
+	if options.JournalPath != "" && options.OperationID == "" {
+		return nil, errors.New("an operation id is required to keep a journal")
+	}
+
+	journaled := map[int64]BulkResult{}
+	var journal io.Writer
+	if options.JournalPath != "" {
+		var err error
+		journaled, err = readBulkJournal(options.JournalPath, options.OperationID)
+		if err != nil {
+			return nil, err
+		}
+		file, err := os.OpenFile(options.JournalPath, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("could not open the bulk journal: %w", err)
+		}
+		defer file.Close()
+		if err = endBulkJournalLine(file); err != nil {
+			return nil, err
+		}
+		journal = file
+	}
+
+	skipped := 0
+	unfinished := []int64{}
+	pending := []int64{}
+	seen := map[int64]bool{}
+	for _, subscriptionID := range subscriptionIDs {
+		if seen[subscriptionID] {
+			skipped++
+			continue
+		}
+		seen[subscriptionID] = true
+		if last, found := journaled[subscriptionID]; found {
+			if last.Started && !options.RerunUnfinished {
+				unfinished = append(unfinished, subscriptionID)
+				continue
+			}
+			if !last.Started && last.Success {
+				skipped++
+				continue
+			}
+		}
+		pending = append(pending, subscriptionID)
+	}
+
+	summary, err := runBulk(ctx, pending, operation, options, journal)
+	summary.Skipped = skipped
+	summary.Unfinished = unfinished
+	return summary, err
+}
+
+// runBulk runs the operation against each subscription, marking it as started in the journal before the operation runs
+// and writing the result after, when the journal is not nil. It stops starting new operations when the context is
+// canceled or the journal cannot be written.
+func runBulk(ctx context.Context, pending []int64, operation BulkOperation, options BulkRunnerOptions, journal io.Writer) (*BulkSummary, error) {
+	summary := &BulkSummary{
+		Unfinished: []int64{},
+		Results:    []BulkResult{},
+	}
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the workers and the collector both write to the journal; the first failure stops the job
+	var journalMutex sync.Mutex
+	var journalErr error
+	record := func(entry BulkResult) error {
+		if journal == nil {
+			return nil
+		}
+		journalMutex.Lock()
+		defer journalMutex.Unlock()
+		if journalErr != nil {
+			return journalErr
+		}
+		if err := writeBulkJournal(journal, entry); err != nil {
+			journalErr = err
+			cancel()
+			return err
+		}
+		return nil
+	}
+
+	limiter := time.NewTicker(time.Duration(float64(time.Second) / options.RequestsPerSecond))
+	defer limiter.Stop()
+
+	work := make(chan int64)
+	results := make(chan BulkResult)
+	var workers sync.WaitGroup
+	for i := 0; i < options.Concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for subscriptionID := range work {
+				result := BulkResult{
+					OperationID:    options.OperationID,
+					SubscriptionID: subscriptionID,
+					StartedAt:      time.Now().UTC().Format(time.RFC3339),
+				}
+				started := result
+				started.Started = true
+				if err := record(started); err != nil {
+					// without the started mark, a crash could lead to the operation being applied twice
+					continue
+				}
+				result.Success = true
+				if err := operation(subscriptionID); err != nil {
+					result.Success = false
+					result.Error = err.Error()
+				}
+				result.CompletedAt = time.Now().UTC().Format(time.RFC3339)
+				results <- result
+			}
+		}()
+	}
+
+	// feed the workers no faster than the rate limit allows, stopping early if the context is canceled
+	go func() {
+		defer close(work)
+		for _, subscriptionID := range pending {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-limiter.C:
+			}
+			select {
+			case <-runCtx.Done():
+				return
+			case work <- subscriptionID:
+			}
+		}
+	}()
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		summary.Results = append(summary.Results, result)
+		if result.Success {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+		}
+		record(result)
+	}
+	if journalErr != nil {
+		return summary, journalErr
+	}
+	return summary, ctx.Err()
+}
+
+// readBulkJournal returns the last journal entry for each subscription in an existing journal. A missing journal is
+// empty, and lines that cannot be read, such as one cut off by a crash, are ignored. A journal with results from a
+// different operation is an error.
+func readBulkJournal(path string, operationID string) (map[int64]BulkResult, error) {
+	journaled := map[int64]BulkResult{}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return journaled, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open the bulk journal: %w", err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		result := BulkResult{}
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil || result.SubscriptionID == 0 {
+			continue
+		}
+		if result.OperationID != operationID {
+			return nil, fmt.Errorf("the bulk journal belongs to operation %q, not %q", result.OperationID, operationID)
+		}
+		journaled[result.SubscriptionID] = result
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read the bulk journal: %w", err)
+	}
+	return journaled, nil
+}
+
+// endBulkJournalLine makes sure new results start on their own line when a crash left the last line of the journal incomplete
+func endBulkJournalLine(journal *os.File) error {
+	info, err := journal.Stat()
+	if err != nil || info.Size() == 0 {
+		return err
+	}
+	last := make([]byte, 1)
+	if _, err := journal.ReadAt(last, info.Size()-1); err != nil {
+		return fmt.Errorf("could not read the bulk journal: %w", err)
+	}
+	if last[0] != '\n' {
+		if _, err := journal.Write([]byte{'\n'}); err != nil {
+			return fmt.Errorf("could not write the bulk journal: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeBulkJournal appends a single result to the journal. Each result is written in one call, so a crash can at most
+// leave the last line incomplete.
+func writeBulkJournal(journal io.Writer, result BulkResult) error {
+	line, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	if _, err := journal.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("could not write the bulk journal: %w", err)
+	}
+	return nil
+}
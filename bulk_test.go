@@ -0,0 +1,239 @@
+package chargify
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBulkOperationResume(t *testing.T) {
+	journalPath := filepath.Join(t.TempDir(), "reprice.jsonl")
+	options := &BulkRunnerOptions{
+		Concurrency:       3,
+		RequestsPerSecond: 1000,
+		JournalPath:       journalPath,
+		OperationID:       "reprice",
+	}
+
+	// the first run fails on the even subscriptions
+	calls := map[int64]int{}
+	callsLock := sync.Mutex{}
+	failEven := true
+	operation := func(subscriptionID int64) error {
+		callsLock.Lock()
+		calls[subscriptionID]++
+		callsLock.Unlock()
+		if failEven && subscriptionID%2 == 0 {
+			return errors.New("payment gateway timeout")
+		}
+		return nil
+	}
+
+	ids := []int64{1, 2, 3, 4, 5, 6, 1}
+	summary, err := RunBulkOperation(context.Background(), ids, operation, options)
+	require.Nil(t, err)
+	assert.Equal(t, 3, summary.Succeeded)
+	assert.Equal(t, 3, summary.Failed)
+	assert.Equal(t, 1, summary.Skipped)
+	assert.Equal(t, 6, len(summary.Results))
+	for _, result := range summary.Results {
+		if result.SubscriptionID%2 == 0 {
+			assert.False(t, result.Success)
+			assert.Equal(t, "payment gateway timeout", result.Error)
+		} else {
+			assert.True(t, result.Success)
+		}
+		assert.NotEmpty(t, result.CompletedAt)
+	}
+
+	// simulate a crash that left a partial line at the end of the journal
+	journal, err := os.OpenFile(journalPath, os.O_APPEND|os.O_WRONLY, 0644)
+	require.Nil(t, err)
+	_, err = journal.WriteString(`{"subscription_id":7,"succ`)
+	require.Nil(t, err)
+	journal.Close()
+
+	// resuming should only run the failures
+	failEven = false
+	summary, err = RunBulkOperation(context.Background(), ids, operation, options)
+	require.Nil(t, err)
+	assert.Equal(t, 3, summary.Succeeded)
+	assert.Equal(t, 0, summary.Failed)
+	assert.Equal(t, 4, summary.Skipped)
+	for _, id := range []int64{1, 3, 5} {
+		assert.Equal(t, 1, calls[id])
+	}
+	for _, id := range []int64{2, 4, 6} {
+		assert.Equal(t, 2, calls[id])
+	}
+
+	journaled, err := readBulkJournal(journalPath, "reprice")
+	require.Nil(t, err)
+	assert.Equal(t, 6, len(journaled))
+	for _, last := range journaled {
+		assert.False(t, last.Started)
+		assert.True(t, last.Success)
+	}
+
+	// a journal belongs to one operation, so another operation cannot resume from it
+	totalCalls := func() int {
+		callsLock.Lock()
+		defer callsLock.Unlock()
+		total := 0
+		for _, count := range calls {
+			total += count
+		}
+		return total
+	}
+	before := totalCalls()
+	_, err = RunBulkOperation(context.Background(), ids, operation, &BulkRunnerOptions{
+		JournalPath: journalPath,
+		OperationID: "cancel-legacy",
+	})
+	assert.NotNil(t, err)
+	_, err = RunBulkOperation(context.Background(), ids, operation, &BulkRunnerOptions{
+		JournalPath: journalPath,
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, before, totalCalls())
+}
+
+// failingJournal accepts a number of writes, then fails every write after
+type failingJournal struct {
+	accept int
+}
+
+func (journal *failingJournal) Write(p []byte) (int, error) {
+	if journal.accept <= 0 {
+		return 0, errors.New("disk full")
+	}
+	journal.accept--
+	return len(p), nil
+}
+
+func TestRunBulkOperationUnfinished(t *testing.T) {
+	journalPath := filepath.Join(t.TempDir(), "coupons.jsonl")
+	// the process crashed while subscription 3 was in flight, after 1 succeeded and 2 failed
+	err := os.WriteFile(journalPath, []byte(`{"operation_id":"coupons","subscription_id":1,"started":true,"success":false}
+{"operation_id":"coupons","subscription_id":1,"success":true}
+{"operation_id":"coupons","subscription_id":2,"started":true,"success":false}
+{"operation_id":"coupons","subscription_id":2,"success":false,"error":"timeout"}
+{"operation_id":"coupons","subscription_id":3,"started":true,"success":false}
+`), 0644)
+	require.Nil(t, err)
+
+	// each subscription is marked as started before the operation runs
+	ran := []int64{}
+	operation := func(subscriptionID int64) error {
+		journaled, err := readBulkJournal(journalPath, "coupons")
+		assert.Nil(t, err)
+		assert.True(t, journaled[subscriptionID].Started)
+		ran = append(ran, subscriptionID)
+		return nil
+	}
+	options := &BulkRunnerOptions{
+		Concurrency:       1,
+		RequestsPerSecond: 1000,
+		JournalPath:       journalPath,
+		OperationID:       "coupons",
+	}
+
+	// the unfinished subscription is reported rather than run again
+	summary, err := RunBulkOperation(context.Background(), []int64{1, 2, 3, 4}, operation, options)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{2, 4}, ran)
+	assert.Equal(t, []int64{3}, summary.Unfinished)
+	assert.Equal(t, 1, summary.Skipped)
+	assert.Equal(t, 2, summary.Succeeded)
+
+	// it is reported again on the next run, until the caller opts in to running it
+	ran = []int64{}
+	summary, err = RunBulkOperation(context.Background(), []int64{1, 2, 3, 4}, operation, options)
+	require.Nil(t, err)
+	assert.Empty(t, ran)
+	assert.Equal(t, []int64{3}, summary.Unfinished)
+	options.RerunUnfinished = true
+	summary, err = RunBulkOperation(context.Background(), []int64{1, 2, 3, 4}, operation, options)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{3}, ran)
+	assert.Empty(t, summary.Unfinished)
+	assert.Equal(t, 3, summary.Skipped)
+}
+
+func TestRunBulkOperationJournalFailure(t *testing.T) {
+	calls := int32(0)
+	operation := func(subscriptionID int64) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}
+	ids := make([]int64, 100)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+
+	runnerOptions := BulkRunnerOptions{
+		Concurrency:       1,
+		RequestsPerSecond: 1000,
+		OperationID:       "reprice",
+	}
+
+	// an operation does not run if it cannot be marked as started
+	summary, err := runBulk(context.Background(), ids, operation, runnerOptions, &failingJournal{})
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+	assert.Zero(t, atomic.LoadInt32(&calls))
+	assert.Empty(t, summary.Results)
+
+	// once a result cannot be recorded, no more operations are started
+	summary, err = runBulk(context.Background(), ids, operation, runnerOptions, &failingJournal{accept: 3})
+	require.NotNil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, 2, len(summary.Results))
+}
+
+func TestRunBulkOperationConcurrency(t *testing.T) {
+	_, err := RunBulkOperation(context.Background(), []int64{1}, nil, nil)
+	assert.NotNil(t, err)
+
+	running := int32(0)
+	most := int32(0)
+	operation := func(subscriptionID int64) error {
+		now := atomic.AddInt32(&running, 1)
+		for {
+			seen := atomic.LoadInt32(&most)
+			if now <= seen || atomic.CompareAndSwapInt32(&most, seen, now) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	}
+	ids := make([]int64, 20)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	summary, err := RunBulkOperation(context.Background(), ids, operation, &BulkRunnerOptions{
+		Concurrency:       2,
+		RequestsPerSecond: 1000,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, 20, summary.Succeeded)
+	assert.LessOrEqual(t, most, int32(2))
+
+	// a canceled job stops feeding work and reports the context error
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	summary, err = RunBulkOperation(ctx, ids, operation, nil)
+	assert.Equal(t, context.Canceled, err)
+	require.NotNil(t, summary)
+	assert.Zero(t, summary.Succeeded)
+}
@@ -0,0 +1,325 @@
+package chargify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// ChargeInput is a one-time charge to add to a subscription
+type ChargeInput struct {
+	AmountInCents           int64  `json:"amount_in_cents"`                     // The amount of the charge, in integer cents
+	Memo                    string `json:"memo"`                                // A description of the charge, shown on the invoice
+	AccrueCharge            *bool  `json:"accrue_charge,omitempty"`             // (Optional) Add the charge to the balance, to be collected at the next renewal, instead of collecting it now
+	DelayCapture            *bool  `json:"delay_capture,omitempty"`             // (Optional) Create the invoice for the charge now, but collect it later
+	InitiateDunning         *bool  `json:"initiate_dunning,omitempty"`          // (Optional) Start dunning if the charge cannot be collected
+	PaymentCollectionMethod string `json:"payment_collection_method,omitempty"` // (Optional) Either automatic or remittance, instead of the subscription setting
+	Taxable                 *bool  `json:"taxable,omitempty"`                   // (Optional) Whether the charge is taxable
+	TaxCode                 string `json:"tax_code,omitempty"`                  // (Optional) The tax code for the charge
+	ComponentID             *int64 `json:"component_id,omitempty"`              // (Optional) Attribute the charge to a component, such as for accounting and taxes
+	PricePointID            *int64 `json:"price_point_id,omitempty"`            // (Optional) The price point of the component
+	Quantity                *int64 `json:"quantity,omitempty"`                  // (Optional) The quantity of the component being charged for
+}
+
+// Charge is the result of a one-time charge on a subscription. All amounts are in integer cents.
+type Charge struct {
+	ID                   int64  `json:"id" mapstructure:"id"`
+	Success              bool   `json:"success" mapstructure:"success"` // Whether the charge was successful
+	Memo                 string `json:"memo" mapstructure:"memo"`
+	AmountInCents        int64  `json:"amount_in_cents" mapstructure:"amount_in_cents"`
+	EndingBalanceInCents int64  `json:"ending_balance_in_cents" mapstructure:"ending_balance_in_cents"` // The balance of the subscription after the charge
+	Type                 string `json:"type" mapstructure:"type"`
+	TransactionType      string `json:"transaction_type" mapstructure:"transaction_type"`
+	SubscriptionID       int64  `json:"subscription_id" mapstructure:"subscription_id"`
+	ProductID            int64  `json:"product_id" mapstructure:"product_id"`
+	ComponentID          int64  `json:"component_id" mapstructure:"component_id"`
+	PaymentID            int64  `json:"payment_id" mapstructure:"payment_id"`
+	CreatedAt            string `json:"created_at" mapstructure:"created_at"`
+}
+
+// Adjustment is a change to the balance of a subscription, without a charge or payment. All amounts are in integer cents.
+type Adjustment struct {
+	ID                   int64  `json:"id" mapstructure:"id"`
+	Success              bool   `json:"success" mapstructure:"success"`
+	Memo                 string `json:"memo" mapstructure:"memo"`
+	AmountInCents        int64  `json:"amount_in_cents" mapstructure:"amount_in_cents"`
+	EndingBalanceInCents int64  `json:"ending_balance_in_cents" mapstructure:"ending_balance_in_cents"` // The balance of the subscription after the adjustment
+	Type                 string `json:"type" mapstructure:"type"`
+	TransactionType      string `json:"transaction_type" mapstructure:"transaction_type"`
+	SubscriptionID       int64  `json:"subscription_id" mapstructure:"subscription_id"`
+	CreatedAt            string `json:"created_at" mapstructure:"created_at"`
+}
+
+// ServiceCredit is an amount of credit issued to a subscription, which is applied to future invoices. All amounts are in integer cents.
+type ServiceCredit struct {
+	ID                   int64  `json:"id" mapstructure:"id"`
+	AmountInCents        int64  `json:"amount_in_cents" mapstructure:"amount_in_cents"`
+	EndingBalanceInCents int64  `json:"ending_balance_in_cents" mapstructure:"ending_balance_in_cents"` // The service credit balance after the credit
+	EntryType            string `json:"entry_type" mapstructure:"entry_type"`                           // Either Credit or Debit
+	Memo                 string `json:"memo" mapstructure:"memo"`
+}
+
+// AccountBalance is a single balance of a subscription
+type AccountBalance struct {
+	BalanceInCents int64 `json:"balance_in_cents" mapstructure:"balance_in_cents"`
+}
+
+// AccountBalances are the balances of a subscription. All amounts are in integer cents.
+type AccountBalances struct {
+	OpenInvoices   AccountBalance `json:"open_invoices" mapstructure:"open_invoices"`     // The amount owed on open invoices
+	ServiceCredits AccountBalance `json:"service_credits" mapstructure:"service_credits"` // The service credit available
+	Prepayments    AccountBalance `json:"prepayments" mapstructure:"prepayments"`         // The prepayments available
+}
+
+// PrepaymentMethod is how a prepayment was paid
+type PrepaymentMethod string
+
+var (
+	// PrepaymentMethodCheck is a prepayment paid by check
+	PrepaymentMethodCheck PrepaymentMethod = "check"
+	// PrepaymentMethodCash is a prepayment paid with cash
+	PrepaymentMethodCash PrepaymentMethod = "cash"
+	// PrepaymentMethodMoneyOrder is a prepayment paid by money order
+	PrepaymentMethodMoneyOrder PrepaymentMethod = "money_order"
+	// PrepaymentMethodACH is a prepayment paid by ACH
+	PrepaymentMethodACH PrepaymentMethod = "ach"
+	// PrepaymentMethodPaypal is a prepayment paid through PayPal
+	PrepaymentMethodPaypal PrepaymentMethod = "paypal_account"
+	// PrepaymentMethodCreditCard is a prepayment charged to a card on file
+	PrepaymentMethodCreditCard PrepaymentMethod = "credit_card"
+	// PrepaymentMethodOther is a prepayment paid any other way
+	PrepaymentMethodOther PrepaymentMethod = "other"
+)
+
+// PrepaymentInput is a prepayment to record on a subscription
+type PrepaymentInput struct {
+	AmountInCents    int64            // The amount of the prepayment, in integer cents
+	Details          string           // Details about the prepayment, such as a check number
+	Memo             string           // A description of the prepayment
+	Method           PrepaymentMethod // How the prepayment was paid
+	PaymentProfileID *int64           // (Optional) The payment profile to charge when the method is credit_card
+}
+
+// Prepayment is a prepayment recorded on a subscription. All amounts are in integer cents.
+type Prepayment struct {
+	ID                     int64  `json:"id" mapstructure:"id"`
+	SubscriptionID         int64  `json:"subscription_id" mapstructure:"subscription_id"`
+	AmountInCents          int64  `json:"amount_in_cents" mapstructure:"amount_in_cents"`
+	RemainingAmountInCents int64  `json:"remaining_amount_in_cents" mapstructure:"remaining_amount_in_cents"` // The amount not yet applied to invoices
+	StartingBalanceInCents int64  `json:"starting_balance_in_cents" mapstructure:"starting_balance_in_cents"`
+	EndingBalanceInCents   int64  `json:"ending_balance_in_cents" mapstructure:"ending_balance_in_cents"`
+	External               bool   `json:"external" mapstructure:"external"` // Whether the prepayment was paid outside of Chargify
+	Memo                   string `json:"memo" mapstructure:"memo"`
+	Details                string `json:"details" mapstructure:"details"`
+	PaymentType            string `json:"payment_type" mapstructure:"payment_type"`
+	CreatedAt              string `json:"created_at" mapstructure:"created_at"`
+}
+
+// centsToAmount formats integer cents as a decimal amount, for the endpoints that do not accept amounts in cents
+func centsToAmount(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// CreateCharge adds a one-time charge to a subscription
+func CreateCharge(subscriptionID int64, input *ChargeInput) (*Charge, error) {
+	if input == nil || input.AmountInCents <= 0 || input.Memo == "" {
+		return nil, errors.New("a positive amount and a memo are required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionChargeCreate],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: map[string]ChargeInput{
+			"charge": *input,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	charge := &Charge{}
+	err = mapstructure.Decode(apiBody["charge"], charge)
+	return charge, err
+}
+
+// CreateAdjustment changes the balance of a subscription by the amount, which is negative for a credit. When target is
+// true, the balance is set to the amount instead.
+func CreateAdjustment(subscriptionID int64, amountInCents int64, memo string, target bool) (*Adjustment, error) {
+	if memo == "" {
+		return nil, errors.New("memo is required")
+	}
+	adjustment := map[string]interface{}{
+		"amount_in_cents": amountInCents,
+		"memo":            memo,
+	}
+	if target {
+		adjustment["adjustment_method"] = "target"
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionAdjustmentCreate],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: map[string]map[string]interface{}{
+			"adjustment": adjustment,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	data := &Adjustment{}
+	err = mapstructure.Decode(apiBody["adjustment"], data)
+	return data, err
+}
+
+// IssueServiceCredit issues service credit to a subscription, which is applied to future invoices
+func IssueServiceCredit(subscriptionID int64, amountInCents int64, memo string) (*ServiceCredit, error) {
+	if amountInCents <= 0 || memo == "" {
+		return nil, errors.New("a positive amount and a memo are required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionServiceCreditCreate],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: map[string]map[string]string{
+			"service_credit": {
+				"amount": centsToAmount(amountInCents),
+				"memo":   memo,
+			},
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	credit := &ServiceCredit{}
+	err = mapstructure.Decode(apiBody["service_credit"], credit)
+	return credit, err
+}
+
+// DeductServiceCredit removes service credit from a subscription
+func DeductServiceCredit(subscriptionID int64, amountInCents int64, memo string) error {
+	if amountInCents <= 0 || memo == "" {
+		return errors.New("a positive amount and a memo are required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionServiceCreditDeduct],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: map[string]map[string]string{
+			"deduction": {
+				"amount": centsToAmount(amountInCents),
+				"memo":   memo,
+			},
+		},
+	}
+	_, err := makeAPICall(options)
+	return err
+}
+
+// GetAccountBalances gets the open invoice, service credit, and prepayment balances of a subscription
+func GetAccountBalances(subscriptionID int64) (*AccountBalances, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionAccountBalancesGet],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	balances := &AccountBalances{}
+	err = mapstructure.Decode(apiBody, balances)
+	return balances, err
+}
+
+// CreatePrepayment records a prepayment on a subscription, which is applied to future invoices
+func CreatePrepayment(subscriptionID int64, input *PrepaymentInput) (*Prepayment, error) {
+	if input == nil || input.AmountInCents <= 0 || input.Memo == "" || input.Details == "" || input.Method == "" {
+		return nil, errors.New("a positive amount, details, memo, and method are required")
+	}
+	prepayment := map[string]interface{}{
+		"amount":  centsToAmount(input.AmountInCents),
+		"details": input.Details,
+		"memo":    input.Memo,
+		"method":  input.Method,
+	}
+	if input.PaymentProfileID != nil {
+		prepayment["payment_profile_id"] = ToInt64(input.PaymentProfileID)
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionPrepaymentCreate],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: map[string]map[string]interface{}{
+			"prepayment": prepayment,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	data := &Prepayment{}
+	err = mapstructure.Decode(apiBody["prepayment"], data)
+	return data, err
+}
+
+// ListPrepayments lists the prepayments recorded on a subscription
+func ListPrepayments(subscriptionID int64, page int) ([]Prepayment, error) {
+	if page < 1 {
+		return nil, errors.New("page must be 1 or higher, not 0 indexed")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionPrepaymentsList],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		QueryParams: &map[string]string{
+			"page": fmt.Sprintf("%d", page),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	// unlike most lists, the prepayments come back under a single prepayments key
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	data := []Prepayment{}
+	err = mapstructure.Decode(apiBody["prepayments"], &data)
+	return data, err
+}
@@ -0,0 +1,72 @@
+package chargify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCentsToAmount(t *testing.T) {
+	assert.Equal(t, "0.00", centsToAmount(0))
+	assert.Equal(t, "0.05", centsToAmount(5))
+	assert.Equal(t, "12.34", centsToAmount(1234))
+	assert.Equal(t, "-1.50", centsToAmount(-150))
+}
+
+func TestChargesValidation(t *testing.T) {
+	_, err := CreateCharge(1, nil)
+	assert.NotNil(t, err)
+	_, err = CreateCharge(1, &ChargeInput{AmountInCents: 100})
+	assert.NotNil(t, err)
+	_, err = CreateAdjustment(1, 100, "", false)
+	assert.NotNil(t, err)
+	_, err = IssueServiceCredit(1, 0, "memo")
+	assert.NotNil(t, err)
+	assert.NotNil(t, DeductServiceCredit(1, 100, ""))
+	_, err = CreatePrepayment(1, &PrepaymentInput{AmountInCents: 100, Memo: "memo"})
+	assert.NotNil(t, err)
+	_, err = ListPrepayments(1, 0)
+	assert.NotNil(t, err)
+}
+
+func TestSubscriptionCharges(t *testing.T) {
+	customer, _, err := createTestCustomer()
+	require.Nil(t, err)
+	_, product, err := createTestProductAndFamily()
+	require.Nil(t, err)
+	subscription, err := CreateSubscriptionForCustomer(customer.Reference, product.Handle, 0, nil)
+	require.Nil(t, err)
+	defer CancelSubscription(subscription.ID, true, "", "")
+
+	charge, err := CreateCharge(subscription.ID, &ChargeInput{
+		AmountInCents: 1000,
+		Memo:          "Replacement hardware",
+		AccrueCharge:  FromBool(true),
+	})
+	require.Nil(t, err)
+	assert.Equal(t, int64(1000), charge.AmountInCents)
+
+	_, err = CreateAdjustment(subscription.ID, -500, "Goodwill", false)
+	assert.Nil(t, err)
+
+	credit, err := IssueServiceCredit(subscription.ID, 250, "Outage")
+	require.Nil(t, err)
+	assert.Equal(t, int64(250), credit.AmountInCents)
+	assert.Nil(t, DeductServiceCredit(subscription.ID, 100, "Correction"))
+
+	balances, err := GetAccountBalances(subscription.ID)
+	require.Nil(t, err)
+	assert.Equal(t, int64(150), balances.ServiceCredits.BalanceInCents)
+
+	_, err = CreatePrepayment(subscription.ID, &PrepaymentInput{
+		AmountInCents: 2000,
+		Details:       "Check 1001",
+		Memo:          "Annual prepayment",
+		Method:        PrepaymentMethodCheck,
+	})
+	require.Nil(t, err)
+	prepayments, err := ListPrepayments(subscription.ID, 1)
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(prepayments))
+}
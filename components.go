@@ -0,0 +1,396 @@
+package chargify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// ComponentKind is the kind of a component, which decides how its quantity is tracked and billed
+type ComponentKind string
+
+var (
+	// ComponentKindMetered is billed in arrears on the usage recorded during the period
+	ComponentKindMetered ComponentKind = "metered_component"
+	// ComponentKindQuantityBased is billed on an allocated quantity, such as seats
+	ComponentKindQuantityBased ComponentKind = "quantity_based_component"
+	// ComponentKindOnOff is a feature that is either enabled at a flat price or not
+	ComponentKindOnOff ComponentKind = "on_off_component"
+	// ComponentKindPrepaidUsage is usage paid for up front, with overage billed at the end of the period
+	ComponentKindPrepaidUsage ComponentKind = "prepaid_usage_component"
+	// ComponentKindEventBased is billed on events sent to Chargify, aggregated by a billing metric
+	ComponentKindEventBased ComponentKind = "event_based_component"
+)
+
+// PricingScheme is how the prices of a component are applied to its quantity
+type PricingScheme string
+
+var (
+	// PricingSchemePerUnit charges the same price for every unit
+	PricingSchemePerUnit PricingScheme = "per_unit"
+	// PricingSchemeVolume charges every unit at the price of the tier the total quantity falls in
+	PricingSchemeVolume PricingScheme = "volume"
+	// PricingSchemeTiered charges the units in each tier at the price of that tier
+	PricingSchemeTiered PricingScheme = "tiered"
+	// PricingSchemeStairstep charges a flat price for the tier the total quantity falls in
+	PricingSchemeStairstep PricingScheme = "stairstep"
+)
+
+// ComponentOveragePricing is the pricing of prepaid usage beyond the quantity that was paid for
+type ComponentOveragePricing struct {
+	PricingScheme PricingScheme `json:"pricing_scheme"`
+	Prices        []Price       `json:"prices"`
+}
+
+// ComponentInput holds the fields for creating a component. Every kind needs a name; the other requirements depend on the kind:
+//
+//	on/off          UnitPrice, and no pricing scheme
+//	others          UnitName and a PricingScheme, with a UnitPrice or a single price for per_unit, or the tiers in Prices
+//	prepaid usage   OveragePricing as well
+//	event based     EventBasedBillingMetricID as well
+type ComponentInput struct {
+	Kind                      ComponentKind            `json:"-"`
+	Name                      string                   `json:"name"`
+	Handle                    string                   `json:"handle,omitempty"`
+	Description               string                   `json:"description,omitempty"`
+	UnitName                  string                   `json:"unit_name,omitempty"`
+	PricingScheme             PricingScheme            `json:"pricing_scheme,omitempty"`
+	UnitPrice                 string                   `json:"unit_price,omitempty"` // The price of a per unit or on/off component, such as "1.50"
+	Prices                    []Price                  `json:"prices,omitempty"`     // The tiers of a volume, tiered, or stairstep component
+	Taxable                   *bool                    `json:"taxable,omitempty"`
+	TaxCode                   string                   `json:"tax_code,omitempty"`
+	Recurring                 *bool                    `json:"recurring,omitempty"` // (Quantity based) Whether the quantity is billed every period
+	UpgradeCharge             ProrationCharge          `json:"upgrade_charge,omitempty"`
+	DowngradeCredit           ProrationCharge          `json:"downgrade_credit,omitempty"`
+	AllowFractionalQuantities *bool                    `json:"allow_fractional_quantities,omitempty"`
+	HideDateRangeOnInvoice    *bool                    `json:"hide_date_range_on_invoice,omitempty"`
+	DisplayOnHostedPage       *bool                    `json:"display_on_hosted_page,omitempty"`
+	OveragePricing            *ComponentOveragePricing `json:"overage_pricing,omitempty"`            // (Prepaid usage) The pricing of usage beyond the prepaid quantity
+	RolloverPrepaidRemainder  *bool                    `json:"rollover_prepaid_remainder,omitempty"` // (Prepaid usage) Whether unused usage carries into the next period
+	RenewPrepaidAllocation    *bool                    `json:"renew_prepaid_allocation,omitempty"`   // (Prepaid usage) Whether the prepaid quantity renews each period
+	ExpirationInterval        *int                     `json:"expiration_interval,omitempty"`        // (Prepaid usage) How long rolled over usage lasts
+	ExpirationIntervalUnit    ProductInterval          `json:"expiration_interval_unit,omitempty"`
+	EventBasedBillingMetricID *int64                   `json:"event_based_billing_metric_id,omitempty"` // (Event based) The billing metric the usage comes from
+}
+
+// ComponentUpdate holds the fields to change on a component. Only the fields that are set are sent to Chargify. Pricing
+// cannot be changed here; change it through the component's price points instead.
+type ComponentUpdate struct {
+	Name                *string          `json:"name,omitempty"`
+	Handle              *string          `json:"handle,omitempty"`
+	Description         *string          `json:"description,omitempty"`
+	Taxable             *bool            `json:"taxable,omitempty"`
+	TaxCode             *string          `json:"tax_code,omitempty"`
+	DisplayOnHostedPage *bool            `json:"display_on_hosted_page,omitempty"`
+	UpgradeCharge       *ProrationCharge `json:"upgrade_charge,omitempty"`
+	DowngradeCredit     *ProrationCharge `json:"downgrade_credit,omitempty"`
+}
+
+// ComponentPricePoint is a set of prices for a component. Every component has a default price point, and alternate price
+// points can be used when allocating or adding the component to a subscription.
+type ComponentPricePoint struct {
+	ID            int64         `json:"id,omitempty" mapstructure:"id"`
+	ComponentID   int64         `json:"component_id,omitempty" mapstructure:"component_id"`
+	Name          string        `json:"name" mapstructure:"name"`
+	Handle        string        `json:"handle,omitempty" mapstructure:"handle"`
+	PricingScheme PricingScheme `json:"pricing_scheme" mapstructure:"pricing_scheme"`
+	Prices        []Price       `json:"prices" mapstructure:"prices"`
+	Default       bool          `json:"default,omitempty" mapstructure:"default"` // only read; change it with SetDefaultComponentPricePoint
+	Type          string        `json:"type,omitempty" mapstructure:"type"`       // Either catalog, default, or custom; only read
+	ArchivedAt    string        `json:"archived_at,omitempty" mapstructure:"archived_at"`
+	CreatedAt     string        `json:"created_at,omitempty" mapstructure:"created_at"`
+	UpdatedAt     string        `json:"updated_at,omitempty" mapstructure:"updated_at"`
+}
+
+// ComponentPricePointUpdate holds the fields to change on a component price point. Prices with an id change that price,
+// and prices without one are added.
+type ComponentPricePointUpdate struct {
+	Name          *string        `json:"name,omitempty"`
+	Handle        *string        `json:"handle,omitempty"`
+	PricingScheme *PricingScheme `json:"pricing_scheme,omitempty"`
+	Prices        []Price        `json:"prices,omitempty"`
+}
+
+func (input *ComponentInput) validate() error {
+	if input.Name == "" {
+		return errors.New("name is required")
+	}
+	switch input.Kind {
+	case ComponentKindOnOff:
+		if input.UnitPrice == "" {
+			return errors.New("an on/off component requires a unit price")
+		}
+		if input.PricingScheme != "" || len(input.Prices) > 0 {
+			return errors.New("an on/off component only has a unit price")
+		}
+		return nil
+	case ComponentKindMetered, ComponentKindQuantityBased, ComponentKindPrepaidUsage, ComponentKindEventBased:
+	default:
+		return fmt.Errorf("unknown component kind: %s", input.Kind)
+	}
+	if input.UnitName == "" {
+		return errors.New("unit name is required")
+	}
+	if err := validatePricing(input.PricingScheme, input.UnitPrice, input.Prices); err != nil {
+		return err
+	}
+	if input.Kind == ComponentKindPrepaidUsage {
+		if input.OveragePricing == nil {
+			return errors.New("a prepaid usage component requires overage pricing")
+		}
+		if err := validatePricing(input.OveragePricing.PricingScheme, "", input.OveragePricing.Prices); err != nil {
+			return fmt.Errorf("overage pricing: %w", err)
+		}
+	}
+	if (input.ExpirationInterval != nil) != (input.ExpirationIntervalUnit != "") {
+		return errors.New("expiration interval and expiration interval unit must be provided together")
+	}
+	if input.Kind == ComponentKindEventBased && input.EventBasedBillingMetricID == nil {
+		return errors.New("an event based component requires a billing metric")
+	}
+	return nil
+}
+
+// validatePricing checks that the prices fit the scheme. A per unit scheme needs a unit price or exactly one price; the
+// others need tiers that start at 1 and follow on from each other, with only the last tier left open ended.
+func validatePricing(scheme PricingScheme, unitPrice string, prices []Price) error {
+	switch scheme {
+	case PricingSchemePerUnit:
+		if unitPrice == "" && len(prices) != 1 {
+			return errors.New("a per unit pricing scheme requires a unit price or a single price")
+		}
+		if unitPrice != "" && len(prices) > 0 {
+			return errors.New("a per unit pricing scheme takes a unit price or prices, not both")
+		}
+		if len(prices) == 1 && prices[0].UnitPrice == "" {
+			return errors.New("the price requires a unit price")
+		}
+		return nil
+	case PricingSchemeVolume, PricingSchemeTiered, PricingSchemeStairstep:
+	case "":
+		return errors.New("pricing scheme is required")
+	default:
+		return fmt.Errorf("unknown pricing scheme: %s", scheme)
+	}
+	if len(prices) == 0 {
+		return fmt.Errorf("a %s pricing scheme requires at least one price", scheme)
+	}
+	for i := range prices {
+		price := prices[i]
+		if price.UnitPrice == "" {
+			return fmt.Errorf("price %d requires a unit price", i+1)
+		}
+		if i == 0 && price.StartingQuantity != 1 {
+			return errors.New("the first price must start at a quantity of 1")
+		}
+		if i > 0 && price.StartingQuantity != prices[i-1].EndingQuantity+1 {
+			return fmt.Errorf("price %d must start right after price %d ends", i+1, i)
+		}
+		if price.EndingQuantity == 0 {
+			if i != len(prices)-1 {
+				return fmt.Errorf("only the last price can be open ended, but price %d has no ending quantity", i+1)
+			}
+		} else if price.EndingQuantity < price.StartingQuantity {
+			return fmt.Errorf("price %d ends before it starts", i+1)
+		}
+	}
+	return nil
+}
+
+// CreateComponent creates a component of the kind set on the input in a product family
+func CreateComponent(productFamilyID int64, input *ComponentInput) (*ProductFamilyComponent, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointComponentCreate],
+		PathParams: &map[string]string{
+			"product_family_id": fmt.Sprintf("%d", productFamilyID),
+			"kind":              string(input.Kind),
+		},
+		Body: map[string]ComponentInput{
+			string(input.Kind): *input,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return decodeComponent(ret)
+}
+
+// UpdateComponent updates only the fields that are set on the input and returns the updated component
+func UpdateComponent(componentID int64, input *ComponentUpdate) (*ProductFamilyComponent, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointComponentUpdate],
+		PathParams: &map[string]string{
+			"component_id": fmt.Sprintf("%d", componentID),
+		},
+		Body: map[string]ComponentUpdate{
+			"component": *input,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return decodeComponent(ret)
+}
+
+// ArchiveComponent archives a component so it can no longer be added to subscriptions. Subscriptions that already have
+// the component keep it.
+func ArchiveComponent(productFamilyID int64, componentID int64) (*ProductFamilyComponent, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointComponentArchive],
+		PathParams: &map[string]string{
+			"product_family_id": fmt.Sprintf("%d", productFamilyID),
+			"component_id":      fmt.Sprintf("%d", componentID),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return decodeComponent(ret)
+}
+
+// ListComponentPricePoints lists the price points of a component, including the default price point
+func ListComponentPricePoints(componentID int64, page int, perPage int) ([]ComponentPricePoint, error) {
+	if page < 1 {
+		return nil, errors.New("page must be 1 or higher, not 0 indexed")
+	}
+	queryParams := map[string]string{
+		"page": fmt.Sprintf("%d", page),
+	}
+	if perPage > 0 {
+		queryParams["per_page"] = fmt.Sprintf("%d", perPage)
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointComponentPricePointsList],
+		PathParams: &map[string]string{
+			"component_id": fmt.Sprintf("%d", componentID),
+		},
+		QueryParams: &queryParams,
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	pricePoints := []ComponentPricePoint{}
+	err = mapstructure.Decode(apiBody["price_points"], &pricePoints)
+	return pricePoints, err
+}
+
+// GetComponentPricePoint gets a single price point of a component
+func GetComponentPricePoint(componentID int64, pricePointID int64) (*ComponentPricePoint, error) {
+	return saveComponentPricePoint(endpointComponentPricePointGet, componentID, pricePointID, nil)
+}
+
+// CreateComponentPricePoint creates a new price point on a component. The result is placed in the input.
+func CreateComponentPricePoint(componentID int64, input *ComponentPricePoint) error {
+	if input == nil {
+		return errors.New("input is required")
+	}
+	if input.Name == "" {
+		return errors.New("name is required")
+	}
+	if err := validatePricing(input.PricingScheme, "", input.Prices); err != nil {
+		return err
+	}
+	pricePoint, err := saveComponentPricePoint(endpointComponentPricePointCreate, componentID, 0, map[string]ComponentPricePoint{
+		"price_point": *input,
+	})
+	if err != nil {
+		return err
+	}
+	*input = *pricePoint
+	return nil
+}
+
+// UpdateComponentPricePoint updates only the fields that are set on the input and returns the updated price point
+func UpdateComponentPricePoint(componentID int64, pricePointID int64, input *ComponentPricePointUpdate) (*ComponentPricePoint, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.PricingScheme != nil && len(input.Prices) > 0 {
+		if err := validatePricing(*input.PricingScheme, "", input.Prices); err != nil {
+			return nil, err
+		}
+	}
+	return saveComponentPricePoint(endpointComponentPricePointUpdate, componentID, pricePointID, map[string]ComponentPricePointUpdate{
+		"price_point": *input,
+	})
+}
+
+// ArchiveComponentPricePoint archives a price point so it can no longer be used. The default price point cannot be archived.
+func ArchiveComponentPricePoint(componentID int64, pricePointID int64) (*ComponentPricePoint, error) {
+	return saveComponentPricePoint(endpointComponentPricePointArchive, componentID, pricePointID, nil)
+}
+
+// UnarchiveComponentPricePoint restores an archived price point
+func UnarchiveComponentPricePoint(componentID int64, pricePointID int64) (*ComponentPricePoint, error) {
+	return saveComponentPricePoint(endpointComponentPricePointUnarchive, componentID, pricePointID, nil)
+}
+
+// SetDefaultComponentPricePoint makes the price point the default for the component and returns the updated component
+func SetDefaultComponentPricePoint(componentID int64, pricePointID int64) (*ProductFamilyComponent, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointComponentPricePointSetDefault],
+		PathParams: &map[string]string{
+			"component_id":   fmt.Sprintf("%d", componentID),
+			"price_point_id": fmt.Sprintf("%d", pricePointID),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return decodeComponent(ret)
+}
+
+// saveComponentPricePoint makes a call against a single price point and decodes the price point that comes back
+func saveComponentPricePoint(endpointName string, componentID int64, pricePointID int64, body interface{}) (*ComponentPricePoint, error) {
+	pathParams := map[string]string{
+		"component_id": fmt.Sprintf("%d", componentID),
+	}
+	if pricePointID != 0 {
+		pathParams["price_point_id"] = fmt.Sprintf("%d", pricePointID)
+	}
+	options := &makeCallOptions{
+		End:        endpoints[endpointName],
+		PathParams: &pathParams,
+		Body:       body,
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	pricePoint := &ComponentPricePoint{}
+	err = mapstructure.Decode(apiBody["price_point"], pricePoint)
+	return pricePoint, err
+}
+
+func decodeComponent(ret APIReturn) (*ProductFamilyComponent, error) {
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	component := &ProductFamilyComponent{}
+	err := mapstructure.Decode(apiBody["component"], component)
+	return component, err
+}
@@ -0,0 +1,153 @@
+package chargify
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentCRUD(t *testing.T) {
+	family, _, err := createTestProductAndFamily()
+	require.Nil(t, err)
+
+	randID := rand.Int63()
+	component, err := CreateComponent(family.ID, &ComponentInput{
+		Kind:          ComponentKindMetered,
+		Name:          fmt.Sprintf("API Calls %d", randID),
+		Handle:        fmt.Sprintf("api-calls-%d", randID),
+		UnitName:      "call",
+		PricingScheme: PricingSchemeTiered,
+		Prices: []Price{
+			{StartingQuantity: 1, EndingQuantity: 1000, UnitPrice: "0.01"},
+			{StartingQuantity: 1001, UnitPrice: "0.005"},
+		},
+	})
+	require.Nil(t, err)
+	require.NotZero(t, component.ID)
+	assert.Equal(t, string(ComponentKindMetered), component.Kind)
+
+	description := "Calls to the public API"
+	updated, err := UpdateComponent(component.ID, &ComponentUpdate{
+		Description: &description,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, description, updated.Description)
+
+	pricePoint := &ComponentPricePoint{
+		Name:          "Flat",
+		PricingScheme: PricingSchemePerUnit,
+		Prices:        []Price{{StartingQuantity: 1, UnitPrice: "0.008"}},
+	}
+	err = CreateComponentPricePoint(component.ID, pricePoint)
+	require.Nil(t, err)
+	require.NotZero(t, pricePoint.ID)
+
+	name := "Flat Rate"
+	updatedPricePoint, err := UpdateComponentPricePoint(component.ID, pricePoint.ID, &ComponentPricePointUpdate{
+		Name: &name,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, name, updatedPricePoint.Name)
+
+	found, err := GetComponentPricePoint(component.ID, pricePoint.ID)
+	require.Nil(t, err)
+	assert.Equal(t, PricingSchemePerUnit, found.PricingScheme)
+
+	pricePoints, err := ListComponentPricePoints(component.ID, 1, 0)
+	require.Nil(t, err)
+	assert.True(t, len(pricePoints) >= 2)
+
+	_, err = ArchiveComponentPricePoint(component.ID, pricePoint.ID)
+	require.Nil(t, err)
+	_, err = UnarchiveComponentPricePoint(component.ID, pricePoint.ID)
+	require.Nil(t, err)
+	withDefault, err := SetDefaultComponentPricePoint(component.ID, pricePoint.ID)
+	require.Nil(t, err)
+	assert.Equal(t, pricePoint.ID, withDefault.DefaultPricePointID)
+
+	archived, err := ArchiveComponent(family.ID, component.ID)
+	require.Nil(t, err)
+	assert.True(t, archived.Archived)
+}
+
+func TestComponentInput(t *testing.T) {
+	_, err := CreateComponent(1, nil)
+	assert.NotNil(t, err)
+
+	input := &ComponentInput{Name: "Seats"}
+	assert.NotNil(t, input.validate())
+	input.Kind = ComponentKindQuantityBased
+	assert.NotNil(t, input.validate())
+	input.UnitName = "seat"
+	assert.NotNil(t, input.validate())
+	input.PricingScheme = PricingSchemePerUnit
+	assert.NotNil(t, input.validate())
+	input.UnitPrice = "5.00"
+	assert.Nil(t, input.validate())
+	input.Prices = []Price{{StartingQuantity: 1, UnitPrice: "5.00"}}
+	assert.NotNil(t, input.validate())
+	input.UnitPrice = ""
+	assert.Nil(t, input.validate())
+
+	// on/off components only take a unit price
+	onOff := &ComponentInput{Kind: ComponentKindOnOff, Name: "Support", UnitPrice: "20.00"}
+	assert.Nil(t, onOff.validate())
+	onOff.PricingScheme = PricingSchemePerUnit
+	assert.NotNil(t, onOff.validate())
+
+	prepaid := &ComponentInput{Kind: ComponentKindPrepaidUsage, Name: "Minutes", UnitName: "minute", PricingScheme: PricingSchemePerUnit, UnitPrice: "0.10"}
+	assert.NotNil(t, prepaid.validate())
+	prepaid.OveragePricing = &ComponentOveragePricing{PricingScheme: PricingSchemePerUnit}
+	assert.NotNil(t, prepaid.validate())
+	prepaid.OveragePricing.Prices = []Price{{StartingQuantity: 1, UnitPrice: "0.15"}}
+	assert.Nil(t, prepaid.validate())
+
+	eventBased := &ComponentInput{Kind: ComponentKindEventBased, Name: "Events", UnitName: "event", PricingScheme: PricingSchemePerUnit, UnitPrice: "0.01"}
+	assert.NotNil(t, eventBased.validate())
+	eventBased.EventBasedBillingMetricID = FromInt64(3)
+	assert.Nil(t, eventBased.validate())
+
+	// the kind is the key of the body rather than a field
+	onOff.PricingScheme = ""
+	encoded, err := json.Marshal(onOff)
+	require.Nil(t, err)
+	assert.Equal(t, `{"name":"Support","unit_price":"20.00"}`, string(encoded))
+}
+
+func TestValidatePricing(t *testing.T) {
+	assert.NotNil(t, validatePricing("", "1.00", nil))
+	assert.NotNil(t, validatePricing("graduated", "1.00", nil))
+	assert.NotNil(t, validatePricing(PricingSchemeVolume, "", nil))
+
+	tiers := []Price{
+		{StartingQuantity: 1, EndingQuantity: 10, UnitPrice: "1.00"},
+		{StartingQuantity: 11, EndingQuantity: 100, UnitPrice: "0.80"},
+		{StartingQuantity: 101, UnitPrice: "0.50"},
+	}
+	for _, scheme := range []PricingScheme{PricingSchemeVolume, PricingSchemeTiered, PricingSchemeStairstep} {
+		assert.Nil(t, validatePricing(scheme, "", tiers))
+	}
+
+	tiers[1].StartingQuantity = 12
+	assert.NotNil(t, validatePricing(PricingSchemeTiered, "", tiers))
+	tiers[1].StartingQuantity = 11
+	tiers[1].EndingQuantity = 0
+	assert.NotNil(t, validatePricing(PricingSchemeTiered, "", tiers))
+	tiers[1].EndingQuantity = 5
+	assert.NotNil(t, validatePricing(PricingSchemeTiered, "", tiers))
+	tiers[1].EndingQuantity = 100
+	tiers[0].StartingQuantity = 0
+	assert.NotNil(t, validatePricing(PricingSchemeTiered, "", tiers))
+	tiers[0].StartingQuantity = 1
+	tiers[2].UnitPrice = ""
+	assert.NotNil(t, validatePricing(PricingSchemeTiered, "", tiers))
+
+	// a price that is sent only has the fields that are set
+	encoded, err := json.Marshal(Price{StartingQuantity: 1, UnitPrice: "1.00"})
+	require.Nil(t, err)
+	assert.Equal(t, `{"starting_quantity":1,"unit_price":"1.00"}`, string(encoded))
+}
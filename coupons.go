@@ -249,3 +249,84 @@ func ListCoupons(params *ListCouponsQueryParams) ([]CouponReturn, error) {
 	}
 	return data, nil
 }
+
+// CouponError is returned when Chargify rejects adding or removing a coupon on a subscription, such as when the coupon
+// is not valid for the product or has expired. It wraps the underlying *ValidationError.
+type CouponError struct {
+	Codes           []string
+	ValidationError *ValidationError
+}
+
+func (e *CouponError) Error() string {
+	return fmt.Sprintf("coupon %s: %s", strings.Join(e.Codes, ", "), e.ValidationError.Error())
+}
+
+// Unwrap returns the underlying *ValidationError
+func (e *CouponError) Unwrap() error {
+	return e.ValidationError
+}
+
+// toCouponError converts a validation error into a *CouponError for the passed in codes, leaving other errors untouched
+func toCouponError(err error, codes []string) error {
+	validationErr := &ValidationError{}
+	if errors.As(err, &validationErr) {
+		return &CouponError{
+			Codes:           codes,
+			ValidationError: validationErr,
+		}
+	}
+	return err
+}
+
+// AddCouponsToSubscription applies one or more coupons to an existing subscription and returns the updated subscription.
+// If Chargify rejects a coupon, a *CouponError is returned.
+func AddCouponsToSubscription(subscriptionID int64, codes ...string) (*Subscription, error) {
+	if len(codes) == 0 {
+		return nil, errors.New("at least one coupon code is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionCouponAdd],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: map[string][]string{
+			"codes": codes,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, toCouponError(err, codes)
+	}
+	return subscriptionFromResponse(ret)
+}
+
+// RemoveCouponFromSubscription removes a coupon from a subscription. If Chargify rejects the removal, such as when the
+// coupon is not applied, a *CouponError is returned.
+func RemoveCouponFromSubscription(subscriptionID int64, code string) error {
+	if code == "" {
+		return errors.New("coupon code is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionCouponRemove],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		QueryParams: &map[string]string{
+			"coupon_code": code,
+		},
+	}
+	_, err := makeAPICall(options)
+	return toCouponError(err, []string{code})
+}
+
+// ListSubscriptionCoupons lists the codes of the coupons currently applied to a subscription
+func ListSubscriptionCoupons(subscriptionID int64) ([]string, error) {
+	subscription, err := GetSubscription(subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.CouponCodes == nil {
+		return []string{}, nil
+	}
+	return subscription.CouponCodes, nil
+}
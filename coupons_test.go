@@ -1,6 +1,7 @@
 package chargify
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -51,3 +52,25 @@ func TestCouponCRD(t *testing.T) {
 	err = ArchiveCoupon(1182341, found.ID)
 	assert.Nil(t, err)
 }
+
+func TestCouponError(t *testing.T) {
+	assert.Nil(t, toCouponError(nil, []string{"CODE"}))
+
+	other := errors.New("chargify server error")
+	assert.Equal(t, other, toCouponError(other, []string{"CODE"}))
+
+	err := toCouponError(&ValidationError{Errors: []string{"Coupon code is not valid for this product."}}, []string{"CODE"})
+	couponErr := &CouponError{}
+	require.True(t, errors.As(err, &couponErr))
+	assert.Equal(t, []string{"CODE"}, couponErr.Codes)
+	assert.Equal(t, "coupon CODE: Coupon code is not valid for this product.", err.Error())
+
+	// the validation error is still reachable
+	validationErr := &ValidationError{}
+	require.True(t, errors.As(err, &validationErr))
+	assert.True(t, validationErr.Contains("not valid"))
+
+	_, err = AddCouponsToSubscription(1)
+	assert.NotNil(t, err)
+	assert.NotNil(t, RemoveCouponFromSubscription(1, ""))
+}
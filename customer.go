@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/mitchellh/mapstructure"
 )
@@ -35,6 +36,29 @@ type Customer struct {
 	PortalInviteLastAcceptedAt string   `json:"portal_invite_last_accepted_at" mapstructure:"portal_invite_last_accepted_at"` //	The timestamp of when the Billing Portal invite was last accepted
 	TaxExempt                  bool     `json:"tax_exempt" mapstructure:"tax_exempt"`                                         //	(Optional) The tax exempt status for the customer. Acceptable values are true or 1 for true and false or 0 for false.
 	VatNumber                  string   `json:"vat_number" mapstructure:"vat_number"`                                         //	(Optional) The VAT number, if applicable
+	ParentID                   *int64   `json:"parent_id,omitempty" mapstructure:"parent_id"`                                 //	(Optional) The ID of the parent customer, such as a company that pays for this customer's subscriptions
+}
+
+// CustomerUpdate holds the fields to change on a customer. Only the fields that are set are sent to Chargify, so
+// fields that are left nil keep their current value.
+type CustomerUpdate struct {
+	FirstName    *string `json:"first_name,omitempty"`   // The first name of the customer
+	LastName     *string `json:"last_name,omitempty"`    // The last name of the customer
+	Email        *string `json:"email,omitempty"`        // The email address of the customer
+	CCEmails     *string `json:"cc_emails,omitempty"`    // A comma-separated list of emails that should be cc’d on all customer communications
+	Organization *string `json:"organization,omitempty"` // The organization of the customer
+	Reference    *string `json:"reference,omitempty"`    // The unique identifier used within your own application for this customer
+	Address      *string `json:"address,omitempty"`      // The customer’s shipping street address
+	Address2     *string `json:"address_2,omitempty"`    // Second line of the customer’s shipping address
+	City         *string `json:"city,omitempty"`         // The customer’s shipping address city
+	State        *string `json:"state,omitempty"`        // The customer’s shipping address state
+	Zip          *string `json:"zip,omitempty"`          // The customer’s shipping address zip code
+	Country      *string `json:"country,omitempty"`      // The customer shipping address country
+	Phone        *string `json:"phone,omitempty"`        // The phone number of the customer
+	Verified     *bool   `json:"verified,omitempty"`     // Is the customer verified to use ACH as a payment method
+	TaxExempt    *bool   `json:"tax_exempt,omitempty"`   // The tax exempt status for the customer
+	VatNumber    *string `json:"vat_number,omitempty"`   // The VAT number, if applicable
+	ParentID     *int64  `json:"parent_id,omitempty"`    // The ID of the parent customer; use RemoveCustomerParent to remove it
 }
 
 // CreateCustomer creates a new customer on chargify
@@ -60,7 +84,8 @@ func CreateCustomer(input *Customer) (*Customer, error) {
 	return customer, err
 }
 
-// UpdateCustomer updates a customer in chargify
+// UpdateCustomer updates a customer in chargify. Note that every field is sent, so empty fields on the input will clear
+// the values on Chargify; use UpdateCustomerFields to only change specific fields.
 func UpdateCustomer(input *Customer) error {
 	body := map[string]Customer{
 		"customer": *input,
@@ -83,6 +108,59 @@ func UpdateCustomer(input *Customer) error {
 	return err
 }
 
+// UpdateCustomerFields updates only the fields that are set on the input and returns the updated customer
+func UpdateCustomerFields(customerID int64, input *CustomerUpdate) (*Customer, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointCustomerUpdate],
+		PathParams: &map[string]string{
+			"id": fmt.Sprintf("%d", customerID),
+		},
+		Body: map[string]CustomerUpdate{
+			"customer": *input,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	customer := &Customer{}
+	err = mapstructure.Decode(apiBody["customer"], customer)
+	return customer, err
+}
+
+// RemoveCustomerParent removes the parent from a customer, so the customer is no longer part of a hierarchy
+func RemoveCustomerParent(customerID int64) (*Customer, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointCustomerUpdate],
+		PathParams: &map[string]string{
+			"id": fmt.Sprintf("%d", customerID),
+		},
+		Body: map[string]map[string]interface{}{
+			"customer": {
+				"parent_id": nil,
+			},
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	customer := &Customer{}
+	err = mapstructure.Decode(apiBody["customer"], customer)
+	return customer, err
+}
+
 // GetCustomerByID gets a customer by chargify id
 func GetCustomerByID(id int) (*Customer, error) {
 	ret, err := makeCall(endpoints[endpointCustomerGet], nil, &map[string]string{
@@ -104,8 +182,11 @@ func GetCustomerByID(id int) (*Customer, error) {
 
 // GetCustomerByReference gets a customer by reference
 func GetCustomerByReference(reference string) (*Customer, error) {
-	ret, err := makeCall(endpoints[endpointCustomerByReferenceGet], nil, &map[string]string{
-		"reference": reference,
+	ret, err := makeAPICall(&makeCallOptions{
+		End: endpoints[endpointCustomerByReferenceGet],
+		QueryParams: &map[string]string{
+			"reference": reference,
+		},
 	})
 	if err != nil || ret.HTTPCode != http.StatusOK {
 		return nil, err
@@ -121,6 +202,46 @@ func GetCustomerByReference(reference string) (*Customer, error) {
 	return nil, err
 }
 
+// UpsertCustomerByReference creates a customer with the input's reference if one does not exist yet, otherwise it updates
+// the existing customer with the input. If another process creates a customer with the same reference between the lookup
+// and the create, the create is retried as an update. The returned bool is true when the customer was created.
+func UpsertCustomerByReference(input *Customer) (*Customer, bool, error) {
+	if input == nil || input.Reference == "" {
+		return nil, false, errors.New("reference is required")
+	}
+	existing, err := GetCustomerByReference(input.Reference)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, false, err
+	}
+	if existing == nil || existing.ID == 0 {
+		created, err := CreateCustomer(input)
+		if err == nil {
+			return created, true, nil
+		}
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || !validationErr.Contains("reference") ||
+			!(validationErr.Contains("taken") || validationErr.Contains("unique")) {
+			return nil, false, err
+		}
+		// the reference was taken after our lookup, so the customer now exists and we update it instead
+		existing, err = GetCustomerByReference(input.Reference)
+		if err != nil {
+			return nil, false, err
+		}
+		if existing == nil || existing.ID == 0 {
+			return nil, false, errors.New("could not find the customer after the reference was taken")
+		}
+	}
+
+	updated := *input
+	updated.ID = existing.ID
+	err = UpdateCustomer(&updated)
+	if err != nil {
+		return nil, false, err
+	}
+	return &updated, false, nil
+}
+
 // DeleteCustomerByID deletes a customer from chargify permanently
 func DeleteCustomerByID(id int64) error {
 	_, err := makeCall(endpoints[endpointCustomerDelete], nil, &map[string]string{
@@ -129,6 +250,86 @@ func DeleteCustomerByID(id int64) error {
 	return err
 }
 
+// ListCustomersQueryParams are the query parameters for listing customers. DateField may be created_at or updated_at and
+// controls which field the start and end filters apply to. Q searches across the customer's name, email, organization and
+// reference.
+type ListCustomersQueryParams struct {
+	Direction     *string `json:"direction"`
+	Page          *int    `json:"page"`
+	PerPage       *int    `json:"per_page"`
+	DateField     *string `json:"date_field"`
+	StartDate     *string `json:"start_date"`
+	EndDate       *string `json:"end_date"`
+	StartDateTime *string `json:"start_datetime"`
+	EndDateTime   *string `json:"end_datetime"`
+	Q             *string `json:"q"`
+}
+
+func (input *ListCustomersQueryParams) toMap() *map[string]string {
+	m := map[string]string{}
+	if input.Direction != nil {
+		m["direction"] = ToString(input.Direction)
+	}
+	if input.Page != nil {
+		m["page"] = fmt.Sprintf("%d", ToInt(input.Page))
+	}
+	if input.PerPage != nil {
+		m["per_page"] = fmt.Sprintf("%d", ToInt(input.PerPage))
+	}
+	if input.DateField != nil {
+		m["date_field"] = ToString(input.DateField)
+	}
+	if input.StartDate != nil {
+		m["start_date"] = ToString(input.StartDate)
+	}
+	if input.EndDate != nil {
+		m["end_date"] = ToString(input.EndDate)
+	}
+	if input.StartDateTime != nil {
+		m["start_datetime"] = ToString(input.StartDateTime)
+	}
+	if input.EndDateTime != nil {
+		m["end_datetime"] = ToString(input.EndDateTime)
+	}
+	if input.Q != nil {
+		m["q"] = ToString(input.Q)
+	}
+	return &m
+}
+
+// ListCustomers lists out the customers based upon the result of the passed in query params
+func ListCustomers(params *ListCustomersQueryParams) ([]Customer, error) {
+	if params == nil {
+		params = &ListCustomersQueryParams{}
+	}
+	options := &makeCallOptions{
+		End:         endpoints[endpointCustomersGet],
+		QueryParams: params.toMap(),
+	}
+
+	data := []Customer{}
+
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return data, err
+	}
+	apiBody, bodyOK := ret.Body.([]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	// so, Chargify violates OWASP best practices by returning these in an array
+	for i := range apiBody {
+		if raw, rawOK := apiBody[i].(map[string]interface{}); rawOK {
+			customer := Customer{}
+			err = mapstructure.Decode(raw["customer"], &customer)
+			if err == nil {
+				data = append(data, customer)
+			}
+		}
+	}
+	return data, nil
+}
+
 // GetCustomers gets the customers for the site
 func GetCustomers(page int, sortDir string) (found []Customer, err error) {
 	sortDir = strings.ToLower(sortDir)
@@ -139,27 +340,36 @@ func GetCustomers(page int, sortDir string) (found []Customer, err error) {
 		return found, errors.New("page must be 1 or higher, not 0 indexed")
 	}
 
-	ret, err := makeCall(endpoints[endpointCustomersGet], map[string]string{
-		"direction": sortDir,
-		"page":      fmt.Sprintf("%d", page),
-	}, nil)
-	if err != nil || ret.HTTPCode != http.StatusOK {
-		return
-	}
+	return ListCustomers(&ListCustomersQueryParams{
+		Direction: &sortDir,
+		Page:      &page,
+	})
+}
 
-	// so, Chargify violates OWASP best practices by returning these in an array
-	temp := ret.Body.([]interface{})
-	for i := range temp {
-		entry := temp[i].(map[string]interface{})
-		custRaw := entry["customer"]
-		customer := Customer{}
-		err = mapstructure.Decode(custRaw, &customer)
-		if err == nil {
-			found = append(found, customer)
+// ListCustomerChildren lists the customers whose parent is the passed in customer. Chargify does not offer a way to filter
+// customers by parent, so this pages through all of the customers on the site and can be slow on large sites.
+func ListCustomerChildren(parentID int64) ([]Customer, error) {
+	children := []Customer{}
+	perPage := 200
+	for page := 1; ; page++ {
+		currentPage := page
+		customers, err := ListCustomers(&ListCustomersQueryParams{
+			Page:    &currentPage,
+			PerPage: &perPage,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i := range customers {
+			if customers[i].ParentID != nil && *customers[i].ParentID == parentID {
+				children = append(children, customers[i])
+			}
+		}
+		if len(customers) < perPage {
+			break
 		}
 	}
-	return found, nil
-
+	return children, nil
 }
 
 // GetCustomerSubscriptions
@@ -185,76 +395,158 @@ func GetCustomerSubscriptions(customerID int) (found []Subscription, err error)
 	return
 }
 
-// SearchForCustomerByReference searches for a customer by it's reference value. It first performs the large search then
-// looks for the substring in the returned values
+// SearchForCustomerByReference finds the customer with the exact reference value
 func SearchForCustomerByReference(reference string) (Customer, error) {
-	found := Customer{}
-
-	customers, err := SearchForCustomersByReference(reference)
+	found, err := GetCustomerByReference(reference)
 	if err != nil {
-		return found, err
-	}
-	for i := range customers {
-		if customers[i].Reference == reference {
-			found = customers[i]
-			break
-		}
+		return Customer{}, err
 	}
-	if found.ID == 0 {
-		return found, errors.New("customer not found")
+	if found == nil || found.ID == 0 {
+		return Customer{}, errors.New("customer not found")
 	}
-	return found, nil
+	return *found, nil
 }
 
-// SearchForCustomersByReference searches all of the customers for a specific reference
+// SearchForCustomersByReference searches all of the customers for a specific reference. Note that the search is a fuzzy
+// match across several fields, so use SearchForCustomerByReference or LookupCustomersByReference for exact matches.
 func SearchForCustomersByReference(reference string) ([]Customer, error) {
-	found := []Customer{}
-	var err error
-	ret, err := makeCall(endpoints[endpointCustomersGet], map[string]string{
-		"q": reference,
-	}, nil)
-	if err != nil || ret.HTTPCode != http.StatusOK {
-		return found, err
-	}
+	return ListCustomers(&ListCustomersQueryParams{
+		Q: &reference,
+	})
+}
 
-	// so, Chargify violates OWASP best practices by returning these in an array
-	temp := ret.Body.([]interface{})
-	for i := range temp {
-		cust := Customer{}
-		entry := temp[i].(map[string]interface{})
-		custRaw := entry["customer"]
-		err = mapstructure.Decode(custRaw, &cust)
+// SearchForCustomersByEmail searches for customers with a specific email address; multiple can exist. Note that the search
+// is a fuzzy match across several fields, so use LookupCustomersByEmail for exact matches.
+func SearchForCustomersByEmail(email string) ([]Customer, error) {
+	return ListCustomers(&ListCustomersQueryParams{
+		Q: &email,
+	})
+}
+
+// customerLookupConcurrency is the number of concurrent requests made by the bulk customer lookups
+const customerLookupConcurrency = 4
+
+// LookupCustomersByReference resolves a list of references to customers, keyed by reference. Each reference is looked up
+// exactly using Chargify's lookup endpoint, with a few requests in flight at once. References that do not match a customer
+// are left out of the result.
+func LookupCustomersByReference(references []string) (map[string]Customer, error) {
+	found, err := lookupCustomersConcurrently(references, func(reference string) ([]Customer, error) {
+		customer, err := GetCustomerByReference(reference)
 		if err != nil {
-			return []Customer{}, err
+			if errors.Is(err, ErrNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if customer == nil {
+			return nil, nil
 		}
-		found = append(found, cust)
+		return []Customer{*customer}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]Customer{}
+	for reference, customers := range found {
+		result[reference] = customers[0]
 	}
-	return found, err
+	return result, nil
 }
 
-// SearchForCustomersByEmail searches for customers with a specific email address; multiple can exist
-func SearchForCustomersByEmail(email string) ([]Customer, error) {
-	found := []Customer{}
-	var err error
-	ret, err := makeCall(endpoints[endpointCustomersGet], map[string]string{
-		"q": email,
-	}, nil)
-	if err != nil || ret.HTTPCode != http.StatusOK {
-		return found, err
-	}
+// LookupCustomersByEmail resolves a list of emails to customers, keyed by email. Since more than one customer can share
+// an email address, each email maps to every customer whose email matches it exactly, ignoring case. Emails that do not
+// match a customer are left out of the result.
+func LookupCustomersByEmail(emails []string) (map[string][]Customer, error) {
+	return lookupCustomersConcurrently(emails, func(email string) ([]Customer, error) {
+		// q is a fuzzy search over several fields, so the exact matches may be anywhere in the results
+		matches := []Customer{}
+		perPage := 200
+		for page := 1; ; page++ {
+			currentPage := page
+			candidates, err := ListCustomers(&ListCustomersQueryParams{
+				Q:       &email,
+				Page:    &currentPage,
+				PerPage: &perPage,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for i := range candidates {
+				if strings.EqualFold(candidates[i].Email, email) {
+					matches = append(matches, candidates[i])
+				}
+			}
+			if len(candidates) < perPage {
+				break
+			}
+		}
+		return matches, nil
+	})
+}
 
-	// so, Chargify violates OWASP best practices by returning these in an array
-	temp := ret.Body.([]interface{})
-	for i := range temp {
-		entry := temp[i].(map[string]interface{})
-		custRaw := entry["customer"]
-		customer := Customer{}
-		err = mapstructure.Decode(custRaw, &customer)
-		if err == nil {
-			found = append(found, customer)
+// lookupCustomersConcurrently runs the lookup for each unique key with bounded concurrency, returning the first error encountered
+func lookupCustomersConcurrently(keys []string, lookup func(key string) ([]Customer, error)) (map[string][]Customer, error) {
+	unique := []string{}
+	seen := map[string]bool{}
+	for _, key := range keys {
+		if key != "" && !seen[key] {
+			seen[key] = true
+			unique = append(unique, key)
 		}
 	}
-	return found, err
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	result := map[string][]Customer{}
+	sem := make(chan struct{}, customerLookupConcurrency)
+
+	for _, key := range unique {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			customers, err := lookup(key)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("could not look up %s: %w", key, err)
+				}
+				return
+			}
+			if len(customers) > 0 {
+				result[key] = customers
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return result, nil
+}
+
+// GetCustomerMetaData gets the customer metadata
+func GetCustomerMetaData(customerID int64) (*MetaData, error) {
+	return getMetaData(MetaDataResourceCustomers, customerID)
+}
+
+// CreateCustomerMetaData sets new metadata on a customer. Only the Name and Value of each entry are sent.
+func CreateCustomerMetaData(customerID int64, entries []MetaDataEntry) ([]MetaDataEntry, error) {
+	return saveMetaData(endpointMetaDataCreate, MetaDataResourceCustomers, customerID, entries)
+}
+
+// UpdateCustomerMetaData updates existing metadata on a customer. Only the Name and Value of each entry are sent.
+func UpdateCustomerMetaData(customerID int64, entries []MetaDataEntry) ([]MetaDataEntry, error) {
+	return saveMetaData(endpointMetaDataUpdate, MetaDataResourceCustomers, customerID, entries)
+}
+
+// DeleteCustomerMetaData removes the metadata with the passed in names from a customer
+func DeleteCustomerMetaData(customerID int64, names ...string) error {
+	return deleteMetaData(MetaDataResourceCustomers, customerID, names)
 }
 
 func createTestCustomer() (*Customer, *PaymentProfile, error) {
@@ -1,6 +1,7 @@
 package chargify
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -56,3 +57,117 @@ func TestCustomerCreation(t *testing.T) {
 	err = DeleteCustomerByID(customer.ID)
 	assert.Nil(t, err)
 }
+
+func TestListCustomersParams(t *testing.T) {
+	params := ListCustomersQueryParams{
+		PerPage:   FromInt(200),
+		DateField: FromString("updated_at"),
+		StartDate: FromString("2022-01-01"),
+		Q:         FromString("test@example.com"),
+	}
+	m := *params.toMap()
+	assert.Equal(t, "200", m["per_page"])
+	assert.Equal(t, "updated_at", m["date_field"])
+	assert.Equal(t, "2022-01-01", m["start_date"])
+	assert.Equal(t, "test@example.com", m["q"])
+	_, ok := m["end_datetime"]
+	assert.False(t, ok)
+}
+
+func TestCustomerBulkLookup(t *testing.T) {
+	first, _, err := createTestCustomer()
+	require.Nil(t, err)
+	defer DeleteCustomerByID(first.ID)
+	second, _, err := createTestCustomer()
+	require.Nil(t, err)
+	defer DeleteCustomerByID(second.ID)
+
+	byReference, err := LookupCustomersByReference([]string{first.Reference, second.Reference, "not-a-real-reference"})
+	require.Nil(t, err)
+	assert.Equal(t, 2, len(byReference))
+	assert.Equal(t, first.ID, byReference[first.Reference].ID)
+	assert.Equal(t, second.ID, byReference[second.Reference].ID)
+
+	byEmail, err := LookupCustomersByEmail([]string{first.Email, second.Email})
+	require.Nil(t, err)
+	assert.Equal(t, 2, len(byEmail))
+	assert.Equal(t, first.ID, byEmail[first.Email][0].ID)
+}
+
+func TestUpsertCustomerByReference(t *testing.T) {
+	customID := rand.Int63n(999999999)
+	input := Customer{
+		FirstName: fmt.Sprintf("First-%d", customID),
+		LastName:  fmt.Sprintf("Last-%d", customID),
+		Email:     fmt.Sprintf("test+%d@example.com", customID),
+		Reference: fmt.Sprintf("test-lib-%d", customID),
+	}
+
+	customer, created, err := UpsertCustomerByReference(&input)
+	require.Nil(t, err)
+	assert.True(t, created)
+	defer DeleteCustomerByID(customer.ID)
+
+	input.City = "Portsmouth"
+	updated, created, err := UpsertCustomerByReference(&input)
+	require.Nil(t, err)
+	assert.False(t, created)
+	assert.Equal(t, customer.ID, updated.ID)
+	assert.Equal(t, "Portsmouth", updated.City)
+}
+
+func TestCustomerUpdateOnlySendsSetFields(t *testing.T) {
+	input := CustomerUpdate{
+		Phone:     FromString(""),
+		TaxExempt: FromBool(false),
+	}
+	body, err := json.Marshal(input)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"phone": "", "tax_exempt": false}`, string(body))
+}
+
+func TestUpdateCustomerFields(t *testing.T) {
+	customer, _, err := createTestCustomer()
+	require.Nil(t, err)
+	defer DeleteCustomerByID(customer.ID)
+
+	updated, err := UpdateCustomerFields(customer.ID, &CustomerUpdate{
+		City: FromString("Portsmouth"),
+	})
+	require.Nil(t, err)
+	assert.Equal(t, "Portsmouth", updated.City)
+	assert.Equal(t, customer.Email, updated.Email)
+	assert.Equal(t, customer.Reference, updated.Reference)
+}
+
+func TestCustomerHierarchy(t *testing.T) {
+	parent, _, err := createTestCustomer()
+	require.Nil(t, err)
+	defer DeleteCustomerByID(parent.ID)
+	child, _, err := createTestCustomer()
+	require.Nil(t, err)
+	defer DeleteCustomerByID(child.ID)
+
+	updated, err := UpdateCustomerFields(child.ID, &CustomerUpdate{
+		ParentID: &parent.ID,
+	})
+	require.Nil(t, err)
+	require.NotNil(t, updated.ParentID)
+	assert.Equal(t, parent.ID, *updated.ParentID)
+
+	children, err := ListCustomerChildren(parent.ID)
+	assert.Nil(t, err)
+	require.Equal(t, 1, len(children))
+	assert.Equal(t, child.ID, children[0].ID)
+
+	_, product, err := createTestProductAndFamily()
+	require.Nil(t, err)
+	subscription, err := CreateSubscriptionBilledToParent(child.Reference, product.Handle, 0, nil)
+	require.Nil(t, err)
+	assert.NotZero(t, subscription.ID)
+	CancelSubscription(subscription.ID, true, "", "")
+
+	updated, err = RemoveCustomerParent(child.ID)
+	require.Nil(t, err)
+	assert.Nil(t, updated.ParentID)
+}
@@ -0,0 +1,158 @@
+package chargify
+
+import (
+	"fmt"
+)
+
+// dunningStepReachedEvent is the event key Chargify records each time a subscription reaches a dunning step
+const dunningStepReachedEvent = "dunning_step_reached"
+
+// Dunner is the dunning process for a subscription. All amounts are in integer cents.
+type Dunner struct {
+	ID                   int64  `json:"id" mapstructure:"id"`
+	RevenueAtRiskInCents int64  `json:"revenue_at_risk_in_cents" mapstructure:"revenue_at_risk_in_cents"` // The amount that will be lost if dunning fails
+	Attempts             int    `json:"attempts" mapstructure:"attempts"`                                 // The number of payment attempts so far
+	LastAttemptedAt      string `json:"last_attempted_at" mapstructure:"last_attempted_at"`               // When the payment was last attempted
+	CreatedAt            string `json:"created_at" mapstructure:"created_at"`                             // When dunning started
+}
+
+// DunningStep is a single step of the site's dunning process
+type DunningStep struct {
+	DayThreshold int    `json:"day_threshold" mapstructure:"day_threshold"` // The number of days after the failed renewal that the step is reached
+	Action       string `json:"action" mapstructure:"action"`               // What happens at the step, such as retry, swap, or cancel
+	SendEmail    bool   `json:"send_email" mapstructure:"send_email"`       // Whether the customer is emailed at the step
+	EmailSubject string `json:"email_subject" mapstructure:"email_subject"`
+	SendSMS      bool   `json:"send_sms" mapstructure:"send_sms"` // Whether the customer is sent a text message at the step
+}
+
+// DunningEvent is a single dunning step reached by a subscription
+type DunningEvent struct {
+	EventID     int64        `json:"event_id"`
+	CreatedAt   string       `json:"created_at"`
+	Message     string       `json:"message"`
+	Dunner      *Dunner      `json:"dunner"`
+	CurrentStep *DunningStep `json:"current_step"`
+	NextStep    *DunningStep `json:"next_step"`
+}
+
+// DunningStatus is the current dunning status of a subscription, derived from its state and its most recent dunning step
+type DunningStatus struct {
+	State            SubscriptionState // The state of the subscription
+	InDunning        bool              // Whether the subscription is delinquent and being dunned
+	NextAttemptAt    string            // When the payment will next be attempted, if the subscription is in dunning
+	Attempts         int               // The number of payment attempts in the most recent dunning process
+	LastAttemptedAt  string            // When the payment was last attempted
+	RevenueAtRisk    int64             // The amount at risk, in integer cents
+	CurrentStep      *DunningStep      // The most recent step reached, or nil if dunning has never started
+	NextStep         *DunningStep      // The next step, or nil if the most recent step was the last
+	DunningStartedAt string            // When the most recent dunning process started
+}
+
+// RetrySubscription immediately retries the failed renewal payment of a past due, soft failure, or unpaid subscription and
+// returns the updated subscription
+func RetrySubscription(subscriptionID int64) (*Subscription, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionRetry],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionFromResponse(ret)
+}
+
+// StopSubscriptionDunning stops dunning for a subscription, leaving it in its current state with no further payment attempts
+// or dunning emails. Chargify has no way to restart dunning once it is stopped; RetrySubscription can still be used to attempt
+// the payment again.
+func StopSubscriptionDunning(subscriptionID int64) (*Subscription, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionCancelDunning],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionFromResponse(ret)
+}
+
+// GetDunningHistory gets every dunning step a subscription has reached, oldest first
+func GetDunningHistory(subscriptionID int64) ([]DunningEvent, error) {
+	history := []DunningEvent{}
+	perPage := 200
+	for page := 1; ; page++ {
+		events, err := ListSubscriptionEvents(int(subscriptionID), &ListSubscriptionEventsQueryParams{
+			Page:      FromInt(page),
+			PerPage:   FromInt(perPage),
+			Direction: FromString("asc"),
+			Filter:    FromString(dunningStepReachedEvent),
+		})
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, dunningEventsFromEvents(events)...)
+		if len(events) < perPage {
+			return history, nil
+		}
+	}
+}
+
+// GetDunningStatus gets the current dunning status of a subscription
+func GetDunningStatus(subscriptionID int64) (*DunningStatus, error) {
+	subscription, err := GetSubscription(subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := GetDunningHistory(subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	return dunningStatusFrom(subscription, history), nil
+}
+
+func dunningEventsFromEvents(events []Event) []DunningEvent {
+	found := []DunningEvent{}
+	for i := range events {
+		if events[i].Key != dunningStepReachedEvent {
+			continue
+		}
+		found = append(found, DunningEvent{
+			EventID:     events[i].ID,
+			CreatedAt:   events[i].CreatedAt,
+			Message:     events[i].Message,
+			Dunner:      events[i].EventSpecificData.Dunner,
+			CurrentStep: events[i].EventSpecificData.CurrentStep,
+			NextStep:    events[i].EventSpecificData.NextStep,
+		})
+	}
+	return found
+}
+
+// dunningStatusFrom combines the subscription with its dunning history, which must be oldest first
+func dunningStatusFrom(subscription *Subscription, history []DunningEvent) *DunningStatus {
+	status := &DunningStatus{
+		State:     subscription.State,
+		InDunning: subscription.State.IsDelinquent(),
+	}
+	if status.InDunning {
+		status.NextAttemptAt = subscription.NextAssessmentAt
+	}
+	if len(history) == 0 {
+		return status
+	}
+	latest := history[len(history)-1]
+	status.CurrentStep = latest.CurrentStep
+	status.NextStep = latest.NextStep
+	if latest.Dunner != nil {
+		status.Attempts = latest.Dunner.Attempts
+		status.LastAttemptedAt = latest.Dunner.LastAttemptedAt
+		status.RevenueAtRisk = latest.Dunner.RevenueAtRiskInCents
+		status.DunningStartedAt = latest.Dunner.CreatedAt
+	}
+	return status
+}
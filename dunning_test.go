@@ -0,0 +1,54 @@
+package chargify
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDunningStatus(t *testing.T) {
+	raw := []interface{}{}
+	err := json.Unmarshal([]byte(`[
+		{"id": 1, "key": "dunning_step_reached", "message": "Step 1", "created_at": "2030-01-02",
+			"event_specific_data": {
+				"dunner": {"id": 9, "revenue_at_risk_in_cents": 5000, "attempts": 1, "last_attempted_at": "2030-01-02", "created_at": "2030-01-01"},
+				"current_step": {"day_threshold": 1, "action": "retry", "send_email": true},
+				"next_step": {"day_threshold": 3, "action": "retry"}
+			}},
+		{"id": 2, "key": "renewal_failure", "message": "Renewal failed"},
+		{"id": 3, "key": "dunning_step_reached", "message": "Step 2", "created_at": "2030-01-04",
+			"event_specific_data": {
+				"dunner": {"id": 9, "revenue_at_risk_in_cents": 5000, "attempts": 2, "last_attempted_at": "2030-01-04", "created_at": "2030-01-01"},
+				"current_step": {"day_threshold": 3, "action": "retry"}
+			}}
+	]`), &raw)
+	require.Nil(t, err)
+	events := []Event{}
+	for i := range raw {
+		event := Event{}
+		require.Nil(t, mapstructure.Decode(raw[i], &event))
+		events = append(events, event)
+	}
+
+	history := dunningEventsFromEvents(events)
+	require.Equal(t, 2, len(history))
+	assert.Equal(t, int64(1), history[0].EventID)
+	assert.Equal(t, 3, history[0].NextStep.DayThreshold)
+
+	status := dunningStatusFrom(&Subscription{State: SubscriptionStatePastDue, NextAssessmentAt: "2030-01-06"}, history)
+	assert.True(t, status.InDunning)
+	assert.Equal(t, "2030-01-06", status.NextAttemptAt)
+	assert.Equal(t, 2, status.Attempts)
+	assert.Equal(t, int64(5000), status.RevenueAtRisk)
+	assert.Equal(t, "2030-01-01", status.DunningStartedAt)
+	assert.Nil(t, status.NextStep)
+
+	// a subscription that was never in dunning
+	status = dunningStatusFrom(&Subscription{State: SubscriptionStateActive, NextAssessmentAt: "2030-02-01"}, []DunningEvent{})
+	assert.False(t, status.InDunning)
+	assert.Empty(t, status.NextAttemptAt)
+	assert.Nil(t, status.CurrentStep)
+}
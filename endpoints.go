@@ -18,6 +18,9 @@ const (
 	endpointCouponArchive   = "coupon_archive"
 	endpointCouponsList     = "coupons_list"
 
+	endpointSubscriptionCouponAdd    = "subscription_coupon_add"
+	endpointSubscriptionCouponRemove = "subscription_coupon_remove"
+
 	endpointCustomerCreate            = "customer_create"
 	endpointCustomerDelete            = "customer_delete"
 	endpointCustomerUpdate            = "customer_update"
@@ -49,6 +52,25 @@ const (
 	endpointProductGetByHandle                = "product_get_by_handle"
 	endpointProductGetForFamily               = "product_get_for_family"
 
+	endpointComponentCreate               = "component_create"
+	endpointComponentUpdate               = "component_update"
+	endpointComponentArchive              = "component_archive"
+	endpointComponentPricePointsList      = "component_price_points_list"
+	endpointComponentPricePointCreate     = "component_price_point_create"
+	endpointComponentPricePointGet        = "component_price_point_get"
+	endpointComponentPricePointUpdate     = "component_price_point_update"
+	endpointComponentPricePointArchive    = "component_price_point_archive"
+	endpointComponentPricePointUnarchive  = "component_price_point_unarchive"
+	endpointComponentPricePointSetDefault = "component_price_point_set_default"
+
+	endpointProductPricePointsList      = "product_price_points_list"
+	endpointProductPricePointCreate     = "product_price_point_create"
+	endpointProductPricePointGet        = "product_price_point_get"
+	endpointProductPricePointUpdate     = "product_price_point_update"
+	endpointProductPricePointArchive    = "product_price_point_archive"
+	endpointProductPricePointUnarchive  = "product_price_point_unarchive"
+	endpointProductPricePointSetDefault = "product_price_point_set_default"
+
 	endpointSubscriptionCreate              = "subscription_create"
 	endpointSubscriptionGet                 = "subscription_get"
 	endpointSubscriptionUpdate              = "subscription_update"
@@ -60,16 +82,79 @@ const (
 	endpointSubscriptionsList               = "subscriptions_list"
 	endpointSubscriptionComponentsGet       = "subscriptions_components_get"
 
-	endpointSubscriptionMigrate   = "subscription_migrate"
-	endpointSubscriptionUpdateNow = "subscription_update_now"
+	endpointSubscriptionMigrate        = "subscription_migrate"
+	endpointSubscriptionMigratePreview = "subscription_migrate_preview"
+
+	endpointSubscriptionHold       = "subscription_hold"
+	endpointSubscriptionHoldUpdate = "subscription_hold_update"
+	endpointSubscriptionResume     = "subscription_resume"
+	endpointSubscriptionReactivate = "subscription_reactivate"
+	endpointSubscriptionOverride   = "subscription_override"
+
+	endpointSubscriptionNotesList  = "subscription_notes_list"
+	endpointSubscriptionNoteGet    = "subscription_note_get"
+	endpointSubscriptionNoteCreate = "subscription_note_create"
+	endpointSubscriptionNoteUpdate = "subscription_note_update"
+	endpointSubscriptionNoteDelete = "subscription_note_delete"
+
+	endpointSubscriptionRetry         = "subscription_retry"
+	endpointSubscriptionCancelDunning = "subscription_cancel_dunning"
 
-	endpointSubscriptionRefund           = "subscription_refund"
-	endpointSubscriptionEvents           = "subscription_events"
-	endpointSubscriptionComponentsUsages = "subscription_components_usages"
+	endpointSubscriptionAllocationCreate  = "subscription_allocation_create"
+	endpointSubscriptionAllocationsList   = "subscription_allocations_list"
+	endpointSubscriptionAllocationsBulk   = "subscription_allocations_bulk"
+	endpointSubscriptionAllocationPreview = "subscription_allocation_preview"
+
+	endpointSubscriptionRefund               = "subscription_refund"
+	endpointSubscriptionEvents               = "subscription_events"
+	endpointSubscriptionComponentsUsages     = "subscription_components_usages"
+	endpointSubscriptionComponentsUsagesList = "subscription_components_usages_list"
+
+	endpointSubscriptionChargeCreate        = "subscription_charge_create"
+	endpointSubscriptionAdjustmentCreate    = "subscription_adjustment_create"
+	endpointSubscriptionServiceCreditCreate = "subscription_service_credit_create"
+	endpointSubscriptionServiceCreditDeduct = "subscription_service_credit_deduct"
+	endpointSubscriptionAccountBalancesGet  = "subscription_account_balances_get"
+	endpointSubscriptionPrepaymentCreate    = "subscription_prepayment_create"
+	endpointSubscriptionPrepaymentsList     = "subscription_prepayments_list"
+
+	endpointSubscriptionRenewalPreview = "subscription_renewal_preview"
+	endpointSubscriptionSignupPreview  = "subscription_signup_preview"
 
 	endpointGetInvoices   = "invoices_get"
 	endpointGetInvoice    = "invoice_get"
 	endpointRefundInvoice = "invoice_refund"
+
+	endpointMetaDataList     = "meta_data_list"
+	endpointMetaDataCreate   = "meta_data_create"
+	endpointMetaDataUpdate   = "meta_data_update"
+	endpointMetaDataDelete   = "meta_data_delete"
+	endpointMetafieldsList   = "metafields_list"
+	endpointMetafieldsCreate = "metafields_create"
+	endpointMetafieldsUpdate = "metafields_update"
+	endpointMetafieldsDelete = "metafields_delete"
+
+	endpointSubscriptionGroupCreate       = "subscription_group_create"
+	endpointSubscriptionGroupGet          = "subscription_group_get"
+	endpointSubscriptionGroupLookup       = "subscription_group_lookup"
+	endpointSubscriptionGroupUpdate       = "subscription_group_update"
+	endpointSubscriptionGroupDelete       = "subscription_group_delete"
+	endpointSubscriptionGroupSignup       = "subscription_group_signup"
+	endpointSubscriptionGroupAddMember    = "subscription_group_add_member"
+	endpointSubscriptionGroupRemoveMember = "subscription_group_remove_member"
+
+	endpointReasonCodesList  = "reason_codes_list"
+	endpointReasonCodeCreate = "reason_code_create"
+	endpointReasonCodeGet    = "reason_code_get"
+	endpointReasonCodeUpdate = "reason_code_update"
+	endpointReasonCodeDelete = "reason_code_delete"
+
+	endpointWebhooksList          = "webhooks_list"
+	endpointWebhooksReplay        = "webhooks_replay"
+	endpointWebhooksEnable        = "webhooks_enable"
+	endpointWebhookEndpointsList  = "webhook_endpoints_list"
+	endpointWebhookEndpointCreate = "webhook_endpoint_create"
+	endpointWebhookEndpointUpdate = "webhook_endpoint_update"
 )
 
 var endpoints = map[string]endpoint{
@@ -101,11 +186,9 @@ var endpoints = map[string]endpoint{
 		},
 	},
 	endpointCustomerByReferenceGet: {
-		method: http.MethodGet,
-		uri:    "customers/lookup.json?reference={reference}",
-		pathParams: []string{
-			"{reference}",
-		},
+		method:     http.MethodGet,
+		uri:        "customers/lookup.json",
+		pathParams: []string{},
 	},
 	endpointCustomerSubscriptionsList: {
 		method: http.MethodGet,
@@ -231,6 +314,83 @@ var endpoints = map[string]endpoint{
 			"{component_handle}",
 		},
 	},
+	endpointComponentCreate: {
+		method: http.MethodPost,
+		uri:    "product_families/{product_family_id}/{kind}s.json",
+		pathParams: []string{
+			"{product_family_id}",
+			"{kind}",
+		},
+	},
+	endpointComponentUpdate: {
+		method: http.MethodPut,
+		uri:    "components/{component_id}.json",
+		pathParams: []string{
+			"{component_id}",
+		},
+	},
+	endpointComponentArchive: {
+		method: http.MethodDelete,
+		uri:    "product_families/{product_family_id}/components/{component_id}.json",
+		pathParams: []string{
+			"{product_family_id}",
+			"{component_id}",
+		},
+	},
+	endpointComponentPricePointsList: {
+		method: http.MethodGet,
+		uri:    "components/{component_id}/price_points.json",
+		pathParams: []string{
+			"{component_id}",
+		},
+	},
+	endpointComponentPricePointCreate: {
+		method: http.MethodPost,
+		uri:    "components/{component_id}/price_points.json",
+		pathParams: []string{
+			"{component_id}",
+		},
+	},
+	endpointComponentPricePointGet: {
+		method: http.MethodGet,
+		uri:    "components/{component_id}/price_points/{price_point_id}.json",
+		pathParams: []string{
+			"{component_id}",
+			"{price_point_id}",
+		},
+	},
+	endpointComponentPricePointUpdate: {
+		method: http.MethodPut,
+		uri:    "components/{component_id}/price_points/{price_point_id}.json",
+		pathParams: []string{
+			"{component_id}",
+			"{price_point_id}",
+		},
+	},
+	endpointComponentPricePointArchive: {
+		method: http.MethodDelete,
+		uri:    "components/{component_id}/price_points/{price_point_id}.json",
+		pathParams: []string{
+			"{component_id}",
+			"{price_point_id}",
+		},
+	},
+	endpointComponentPricePointUnarchive: {
+		method: http.MethodPut,
+		uri:    "components/{component_id}/price_points/{price_point_id}/unarchive.json",
+		pathParams: []string{
+			"{component_id}",
+			"{price_point_id}",
+		},
+	},
+	endpointComponentPricePointSetDefault: {
+		method: http.MethodPut,
+		uri:    "components/{component_id}/price_points/{price_point_id}/default.json",
+		pathParams: []string{
+			"{component_id}",
+			"{price_point_id}",
+		},
+	},
 	endpointProductFamiliesGet: {
 		method:     http.MethodGet,
 		uri:        "product_families.json",
@@ -279,6 +439,60 @@ var endpoints = map[string]endpoint{
 			"{familyID}",
 		},
 	},
+	endpointProductPricePointsList: {
+		method: http.MethodGet,
+		uri:    "products/{productID}/price_points.json",
+		pathParams: []string{
+			"{productID}",
+		},
+	},
+	endpointProductPricePointCreate: {
+		method: http.MethodPost,
+		uri:    "products/{productID}/price_points.json",
+		pathParams: []string{
+			"{productID}",
+		},
+	},
+	endpointProductPricePointGet: {
+		method: http.MethodGet,
+		uri:    "products/{productID}/price_points/{pricePointID}.json",
+		pathParams: []string{
+			"{productID}",
+			"{pricePointID}",
+		},
+	},
+	endpointProductPricePointUpdate: {
+		method: http.MethodPut,
+		uri:    "products/{productID}/price_points/{pricePointID}.json",
+		pathParams: []string{
+			"{productID}",
+			"{pricePointID}",
+		},
+	},
+	endpointProductPricePointArchive: {
+		method: http.MethodDelete,
+		uri:    "products/{productID}/price_points/{pricePointID}.json",
+		pathParams: []string{
+			"{productID}",
+			"{pricePointID}",
+		},
+	},
+	endpointProductPricePointUnarchive: {
+		method: http.MethodPatch,
+		uri:    "products/{productID}/price_points/{pricePointID}/unarchive.json",
+		pathParams: []string{
+			"{productID}",
+			"{pricePointID}",
+		},
+	},
+	endpointProductPricePointSetDefault: {
+		method: http.MethodPatch,
+		uri:    "products/{productID}/price_points/{pricePointID}/default.json",
+		pathParams: []string{
+			"{productID}",
+			"{pricePointID}",
+		},
+	},
 	// subscriptions
 	endpointSubscriptionCreate: {
 		method:     http.MethodPost,
@@ -334,6 +548,93 @@ var endpoints = map[string]endpoint{
 			"{subscriptionID}",
 		},
 	},
+	endpointSubscriptionHold: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/hold.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionHoldUpdate: {
+		method: http.MethodPut,
+		uri:    "subscriptions/{subscriptionID}/hold.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionResume: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/resume.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionNotesList: {
+		method: http.MethodGet,
+		uri:    "subscriptions/{subscriptionID}/notes.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionNoteGet: {
+		method: http.MethodGet,
+		uri:    "subscriptions/{subscriptionID}/notes/{noteID}.json",
+		pathParams: []string{
+			"{subscriptionID}",
+			"{noteID}",
+		},
+	},
+	endpointSubscriptionNoteCreate: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/notes.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionNoteUpdate: {
+		method: http.MethodPut,
+		uri:    "subscriptions/{subscriptionID}/notes/{noteID}.json",
+		pathParams: []string{
+			"{subscriptionID}",
+			"{noteID}",
+		},
+	},
+	endpointSubscriptionNoteDelete: {
+		method: http.MethodDelete,
+		uri:    "subscriptions/{subscriptionID}/notes/{noteID}.json",
+		pathParams: []string{
+			"{subscriptionID}",
+			"{noteID}",
+		},
+	},
+	endpointSubscriptionRetry: {
+		method: http.MethodPut,
+		uri:    "subscriptions/{subscriptionID}/retry.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionCancelDunning: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/cancel_dunning.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionOverride: {
+		method: http.MethodPut,
+		uri:    "subscriptions/{subscriptionID}/override.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionReactivate: {
+		method: http.MethodPut,
+		uri:    "subscriptions/{subscriptionID}/reactivate.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
 	endpointSubscriptionMigrate: {
 		method: http.MethodPost,
 		uri:    "subscriptions/{subscriptionID}/migrations",
@@ -341,6 +642,43 @@ var endpoints = map[string]endpoint{
 			"{subscriptionID}",
 		},
 	},
+	endpointSubscriptionMigratePreview: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/migrations/preview.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionAllocationCreate: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/components/{componentID}/allocations.json",
+		pathParams: []string{
+			"{subscriptionID}",
+			"{componentID}",
+		},
+	},
+	endpointSubscriptionAllocationsList: {
+		method: http.MethodGet,
+		uri:    "subscriptions/{subscriptionID}/components/{componentID}/allocations.json",
+		pathParams: []string{
+			"{subscriptionID}",
+			"{componentID}",
+		},
+	},
+	endpointSubscriptionAllocationsBulk: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/allocations.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionAllocationPreview: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/allocations/preview.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
 	endpointSubscriptionRefund: {
 		method: http.MethodPost,
 		uri:    "subscriptions/{subscriptionID}/refunds",
@@ -363,6 +701,14 @@ var endpoints = map[string]endpoint{
 			"{componentID}",
 		},
 	},
+	endpointSubscriptionComponentsUsagesList: {
+		method: http.MethodGet,
+		uri:    "subscriptions/{subscriptionID}/components/{componentID}/usages.json",
+		pathParams: []string{
+			"{subscriptionID}",
+			"{componentID}",
+		},
+	},
 	endpointSubscriptionPurge: {
 		method: http.MethodPost,
 		uri:    "subscriptions/{subscriptionID}/purge.json",
@@ -376,6 +722,71 @@ var endpoints = map[string]endpoint{
 		pathParams: []string{},
 	},
 
+	// charges, credits, and balances
+	endpointSubscriptionChargeCreate: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/charges.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionAdjustmentCreate: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/adjustments.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionServiceCreditCreate: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/service_credits.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionServiceCreditDeduct: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/service_credit_deductions.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionAccountBalancesGet: {
+		method: http.MethodGet,
+		uri:    "subscriptions/{subscriptionID}/account_balances.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionPrepaymentCreate: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/prepayments.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionPrepaymentsList: {
+		method: http.MethodGet,
+		uri:    "subscriptions/{subscriptionID}/prepayments.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+
+	// previews
+	endpointSubscriptionRenewalPreview: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/renewals/preview.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionSignupPreview: {
+		method:     http.MethodPost,
+		uri:        "subscriptions/preview.json",
+		pathParams: []string{},
+	},
+
 	// invoices
 	endpointGetInvoices: {
 		method:     http.MethodGet,
@@ -417,9 +828,202 @@ var endpoints = map[string]endpoint{
 			"{couponID}",
 		},
 	},
+	endpointSubscriptionCouponAdd: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/add_coupon.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionCouponRemove: {
+		method: http.MethodDelete,
+		uri:    "subscriptions/{subscriptionID}/remove_coupon.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
 	endpointCouponsList: {
 		method:     http.MethodGet,
 		uri:        "coupons.json",
 		pathParams: []string{},
 	},
+	// subscription groups
+	endpointSubscriptionGroupCreate: {
+		method:     http.MethodPost,
+		uri:        "subscription_groups.json",
+		pathParams: []string{},
+	},
+	endpointSubscriptionGroupGet: {
+		method: http.MethodGet,
+		uri:    "subscription_groups/{uid}.json",
+		pathParams: []string{
+			"{uid}",
+		},
+	},
+	endpointSubscriptionGroupLookup: {
+		method:     http.MethodGet,
+		uri:        "subscription_groups/lookup.json",
+		pathParams: []string{},
+	},
+	endpointSubscriptionGroupUpdate: {
+		method: http.MethodPut,
+		uri:    "subscription_groups/{uid}.json",
+		pathParams: []string{
+			"{uid}",
+		},
+	},
+	endpointSubscriptionGroupDelete: {
+		method: http.MethodDelete,
+		uri:    "subscription_groups/{uid}.json",
+		pathParams: []string{
+			"{uid}",
+		},
+	},
+	endpointSubscriptionGroupSignup: {
+		method:     http.MethodPost,
+		uri:        "subscription_groups/signup.json",
+		pathParams: []string{},
+	},
+	endpointSubscriptionGroupAddMember: {
+		method: http.MethodPost,
+		uri:    "subscriptions/{subscriptionID}/group.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+	endpointSubscriptionGroupRemoveMember: {
+		method: http.MethodDelete,
+		uri:    "subscriptions/{subscriptionID}/group.json",
+		pathParams: []string{
+			"{subscriptionID}",
+		},
+	},
+
+	// reason codes
+	endpointReasonCodesList: {
+		method:     http.MethodGet,
+		uri:        "reason_codes.json",
+		pathParams: []string{},
+	},
+	endpointReasonCodeCreate: {
+		method:     http.MethodPost,
+		uri:        "reason_codes.json",
+		pathParams: []string{},
+	},
+	endpointReasonCodeGet: {
+		method: http.MethodGet,
+		uri:    "reason_codes/{reasonCodeID}.json",
+		pathParams: []string{
+			"{reasonCodeID}",
+		},
+	},
+	endpointReasonCodeUpdate: {
+		method: http.MethodPut,
+		uri:    "reason_codes/{reasonCodeID}.json",
+		pathParams: []string{
+			"{reasonCodeID}",
+		},
+	},
+	endpointReasonCodeDelete: {
+		method: http.MethodDelete,
+		uri:    "reason_codes/{reasonCodeID}.json",
+		pathParams: []string{
+			"{reasonCodeID}",
+		},
+	},
+
+	// webhooks
+	endpointWebhooksList: {
+		method:     http.MethodGet,
+		uri:        "webhooks.json",
+		pathParams: []string{},
+	},
+	endpointWebhooksReplay: {
+		method:     http.MethodPost,
+		uri:        "webhooks/replay.json",
+		pathParams: []string{},
+	},
+	endpointWebhooksEnable: {
+		method:     http.MethodPut,
+		uri:        "webhooks/settings.json",
+		pathParams: []string{},
+	},
+	endpointWebhookEndpointsList: {
+		method:     http.MethodGet,
+		uri:        "endpoints.json",
+		pathParams: []string{},
+	},
+	endpointWebhookEndpointCreate: {
+		method:     http.MethodPost,
+		uri:        "endpoints.json",
+		pathParams: []string{},
+	},
+	endpointWebhookEndpointUpdate: {
+		method: http.MethodPut,
+		uri:    "endpoints/{endpointID}.json",
+		pathParams: []string{
+			"{endpointID}",
+		},
+	},
+	// metadata and metafields; resourceType is either customers or subscriptions
+	endpointMetaDataList: {
+		method: http.MethodGet,
+		uri:    "{resourceType}/{resourceID}/metadata.json",
+		pathParams: []string{
+			"{resourceType}",
+			"{resourceID}",
+		},
+	},
+	endpointMetaDataCreate: {
+		method: http.MethodPost,
+		uri:    "{resourceType}/{resourceID}/metadata.json",
+		pathParams: []string{
+			"{resourceType}",
+			"{resourceID}",
+		},
+	},
+	endpointMetaDataUpdate: {
+		method: http.MethodPut,
+		uri:    "{resourceType}/{resourceID}/metadata.json",
+		pathParams: []string{
+			"{resourceType}",
+			"{resourceID}",
+		},
+	},
+	endpointMetaDataDelete: {
+		method: http.MethodDelete,
+		uri:    "{resourceType}/{resourceID}/metadata.json",
+		pathParams: []string{
+			"{resourceType}",
+			"{resourceID}",
+		},
+	},
+	endpointMetafieldsList: {
+		method: http.MethodGet,
+		uri:    "{resourceType}/metafields.json",
+		pathParams: []string{
+			"{resourceType}",
+		},
+	},
+	endpointMetafieldsCreate: {
+		method: http.MethodPost,
+		uri:    "{resourceType}/metafields.json",
+		pathParams: []string{
+			"{resourceType}",
+		},
+	},
+	endpointMetafieldsUpdate: {
+		method: http.MethodPut,
+		uri:    "{resourceType}/metafields.json",
+		pathParams: []string{
+			"{resourceType}",
+		},
+	},
+	endpointMetafieldsDelete: {
+		method: http.MethodDelete,
+		uri:    "{resourceType}/metafields.json",
+		pathParams: []string{
+			"{resourceType}",
+		},
+	},
 }
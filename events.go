@@ -24,6 +24,10 @@ type EventSpecificData struct {
 	ComponentHandle            string             `json:"component_handle" mapstructure:"component_handle"`
 	Memo                       string             `json:"memo" mapstructure:"memo"`
 	AllocationDetails          []AllocationDetail `json:"allocation_details" mapstructure:"allocation_details"`
+	// only sent with dunning_step_reached events
+	Dunner      *Dunner      `json:"dunner,omitempty" mapstructure:"dunner"`
+	CurrentStep *DunningStep `json:"current_step,omitempty" mapstructure:"current_step"`
+	NextStep    *DunningStep `json:"next_step,omitempty" mapstructure:"next_step"`
 }
 type Event struct {
 	ID                int64             `json:"id" mapstructure:"id"` //	The customer ID in Chargify
@@ -1,7 +1,39 @@
 package chargify
 
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// MetaDataResource is the type of resource that metadata and metafields can be attached to
+type MetaDataResource string
+
+var (
+	// MetaDataResourceCustomers represents metadata attached to customers
+	MetaDataResourceCustomers MetaDataResource = "customers"
+	// MetaDataResourceSubscriptions represents metadata attached to subscriptions
+	MetaDataResourceSubscriptions MetaDataResource = "subscriptions"
+)
+
+// MetafieldInputType is the type of input a metafield accepts
+type MetafieldInputType string
+
+var (
+	// MetafieldInputTypeText accepts free-form text
+	MetafieldInputTypeText MetafieldInputType = "text"
+	// MetafieldInputTypeBalanceTracker tracks a numeric balance
+	MetafieldInputTypeBalanceTracker MetafieldInputType = "balance_tracker"
+	// MetafieldInputTypeRadio accepts one of the values in the metafield's enum, displayed as radio buttons
+	MetafieldInputTypeRadio MetafieldInputType = "radio"
+	// MetafieldInputTypeDropdown accepts one of the values in the metafield's enum, displayed as a dropdown
+	MetafieldInputTypeDropdown MetafieldInputType = "dropdown"
+)
+
 // MetaDataEntry represents a single key/value meta data entry
 type MetaDataEntry struct {
+	ID         int64  `json:"id,omitempty" mapstructure:"id"`
 	Value      string `json:"value" mapstructure:"value"`
 	ResourceID int64  `json:"resource_id" mapstructure:"resource_id"`
 	Name       string `json:"name" mapstructure:"name"`
@@ -15,3 +47,226 @@ type MetaData struct {
 	PerPage     int64           `json:"per_page" mapstructure:"per_page"`
 	MetaData    []MetaDataEntry `json:"metadata" mapstructure:"metadata"`
 }
+
+// MetafieldScope controls where a metafield is displayed. Each value is "1" to show the field in that location or "0" to hide it.
+type MetafieldScope struct {
+	CSV        string `json:"csv,omitempty" mapstructure:"csv"`                 // Include the field in CSV exports
+	Statements string `json:"statements,omitempty" mapstructure:"statements"`   // Show the field on statements
+	Invoices   string `json:"invoices,omitempty" mapstructure:"invoices"`       // Show the field on invoices
+	Portal     string `json:"portal,omitempty" mapstructure:"portal"`           // Show the field in the billing portal
+	PublicShow string `json:"public_show,omitempty" mapstructure:"public_show"` // Show the field on public signup pages
+	PublicEdit string `json:"public_edit,omitempty" mapstructure:"public_edit"` // Allow the field to be edited on public signup pages
+}
+
+// Metafield is the definition of a metadata field that can be set on customers or subscriptions
+type Metafield struct {
+	ID        int64              `json:"id,omitempty" mapstructure:"id"`
+	Name      string             `json:"name" mapstructure:"name"`                       // The name of the field, which is the key used when setting metadata
+	Scope     *MetafieldScope    `json:"scope,omitempty" mapstructure:"scope"`           // Where the field is displayed
+	DataCount int64              `json:"data_count,omitempty" mapstructure:"data_count"` // The number of resources with a value for the field
+	InputType MetafieldInputType `json:"input_type,omitempty" mapstructure:"input_type"` // The type of input the field accepts
+	Enum      []string           `json:"enum,omitempty" mapstructure:"enum"`             // The allowed values when the input type is radio or dropdown
+}
+
+// Metafields represents a pageable return of a metafields request
+type Metafields struct {
+	TotalCount  int64       `json:"total_count" mapstructure:"total_count"`
+	CurrentPage int64       `json:"current_page" mapstructure:"current_page"`
+	TotalPages  int64       `json:"total_pages" mapstructure:"total_pages"`
+	PerPage     int64       `json:"per_page" mapstructure:"per_page"`
+	Metafields  []Metafield `json:"metafields" mapstructure:"metafields"`
+}
+
+// getMetaData gets the metadata for a single customer or subscription
+func getMetaData(resourceType MetaDataResource, resourceID int64) (*MetaData, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointMetaDataList],
+		PathParams: &map[string]string{
+			"resourceType": string(resourceType),
+			"resourceID":   fmt.Sprintf("%d", resourceID),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	data := &MetaData{}
+	err = mapstructure.Decode(apiBody, data)
+	return data, err
+}
+
+// saveMetaData creates or updates metadata on a single customer or subscription, depending on the endpoint
+func saveMetaData(end string, resourceType MetaDataResource, resourceID int64, entries []MetaDataEntry) ([]MetaDataEntry, error) {
+	if len(entries) == 0 {
+		return nil, errors.New("at least one metadata entry is required")
+	}
+	// only the name and value are accepted by Chargify
+	metadata := make([]map[string]string, len(entries))
+	for i := range entries {
+		if entries[i].Name == "" {
+			return nil, errors.New("name is required for every metadata entry")
+		}
+		metadata[i] = map[string]string{
+			"name":  entries[i].Name,
+			"value": entries[i].Value,
+		}
+	}
+	options := &makeCallOptions{
+		End: endpoints[end],
+		PathParams: &map[string]string{
+			"resourceType": string(resourceType),
+			"resourceID":   fmt.Sprintf("%d", resourceID),
+		},
+		Body: map[string][]map[string]string{
+			"metadata": metadata,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	// the saved entries come back as an array
+	apiBody, bodyOK := ret.Body.([]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	saved := []MetaDataEntry{}
+	err = mapstructure.Decode(apiBody, &saved)
+	return saved, err
+}
+
+// deleteMetaData removes the metadata with the passed in names from a single customer or subscription
+func deleteMetaData(resourceType MetaDataResource, resourceID int64, names []string) error {
+	if len(names) == 0 {
+		return errors.New("at least one name is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointMetaDataDelete],
+		PathParams: &map[string]string{
+			"resourceType": string(resourceType),
+			"resourceID":   fmt.Sprintf("%d", resourceID),
+		},
+		MultiQueryParams: &map[string][]string{
+			"names[]": names,
+		},
+	}
+	_, err := makeAPICall(options)
+	return err
+}
+
+// ListMetafields lists the metafield definitions for customers or subscriptions
+func ListMetafields(resourceType MetaDataResource, page int) (*Metafields, error) {
+	if page < 1 {
+		return nil, errors.New("page must be 1 or higher, not 0 indexed")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointMetafieldsList],
+		PathParams: &map[string]string{
+			"resourceType": string(resourceType),
+		},
+		QueryParams: &map[string]string{
+			"page": fmt.Sprintf("%d", page),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	data := &Metafields{}
+	err = mapstructure.Decode(apiBody, data)
+	return data, err
+}
+
+// CreateMetafield creates a new metafield definition for customers or subscriptions. The result is placed in the input.
+func CreateMetafield(resourceType MetaDataResource, input *Metafield) error {
+	if input.Name == "" {
+		return errors.New("name is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointMetafieldsCreate],
+		PathParams: &map[string]string{
+			"resourceType": string(resourceType),
+		},
+		Body: map[string]Metafield{
+			"metafields": *input,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return err
+	}
+	return decodeSavedMetafield(ret, input)
+}
+
+// UpdateMetafield updates the metafield definition currently named currentName. Passing in a different name on the input
+// renames the field. The result is placed in the input.
+func UpdateMetafield(resourceType MetaDataResource, currentName string, input *Metafield) error {
+	if currentName == "" {
+		return errors.New("currentName is required")
+	}
+	body := map[string]map[string]interface{}{
+		"metafields": {
+			"current_name": currentName,
+		},
+	}
+	if input.Name != "" {
+		body["metafields"]["name"] = input.Name
+	}
+	if input.Scope != nil {
+		body["metafields"]["scope"] = input.Scope
+	}
+	if input.InputType != "" {
+		body["metafields"]["input_type"] = input.InputType
+	}
+	if input.Enum != nil {
+		body["metafields"]["enum"] = input.Enum
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointMetafieldsUpdate],
+		PathParams: &map[string]string{
+			"resourceType": string(resourceType),
+		},
+		Body: body,
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return err
+	}
+	return decodeSavedMetafield(ret, input)
+}
+
+// DeleteMetafield deletes a metafield definition, along with all of the metadata stored for it
+func DeleteMetafield(resourceType MetaDataResource, name string) error {
+	if name == "" {
+		return errors.New("name is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointMetafieldsDelete],
+		PathParams: &map[string]string{
+			"resourceType": string(resourceType),
+		},
+		QueryParams: &map[string]string{
+			"name": name,
+		},
+	}
+	_, err := makeAPICall(options)
+	return err
+}
+
+// decodeSavedMetafield decodes the result of creating or updating a metafield; Chargify returns an array
+// of the saved metafields, even when only one was sent
+func decodeSavedMetafield(ret APIReturn, input *Metafield) error {
+	apiBody, bodyOK := ret.Body.([]interface{})
+	if !bodyOK || len(apiBody) == 0 {
+		return errors.New("could not understand server response")
+	}
+	return mapstructure.Decode(apiBody[0], input)
+}
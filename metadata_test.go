@@ -0,0 +1,52 @@
+package chargify
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomerMetaDataCRUD(t *testing.T) {
+	customer, _, err := createTestCustomer()
+	require.Nil(t, err)
+	defer DeleteCustomerByID(customer.ID)
+
+	fieldName := fmt.Sprintf("test-field-%d", rand.Int63n(999999999))
+	field := &Metafield{
+		Name:      fieldName,
+		InputType: MetafieldInputTypeText,
+	}
+	err = CreateMetafield(MetaDataResourceCustomers, field)
+	require.Nil(t, err)
+	assert.Equal(t, fieldName, field.Name)
+	defer DeleteMetafield(MetaDataResourceCustomers, fieldName)
+
+	fields, err := ListMetafields(MetaDataResourceCustomers, 1)
+	assert.Nil(t, err)
+	require.NotNil(t, fields)
+	assert.NotZero(t, fields.TotalCount)
+
+	saved, err := CreateCustomerMetaData(customer.ID, []MetaDataEntry{
+		{Name: fieldName, Value: "first"},
+	})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(saved))
+	assert.Equal(t, "first", saved[0].Value)
+
+	_, err = UpdateCustomerMetaData(customer.ID, []MetaDataEntry{
+		{Name: fieldName, Value: "second"},
+	})
+	assert.Nil(t, err)
+
+	found, err := GetCustomerMetaData(customer.ID)
+	assert.Nil(t, err)
+	require.NotNil(t, found)
+	require.NotZero(t, len(found.MetaData))
+	assert.Equal(t, "second", found.MetaData[0].Value)
+
+	err = DeleteCustomerMetaData(customer.ID, fieldName)
+	assert.Nil(t, err)
+}
@@ -41,6 +41,24 @@ type PaymentProfile struct {
 	CardType              string      `json:"card_type" mapstructure:"card_type"`                               // 	Can be any of the following visa, master, discover, american_express, diners_club, jcb, switch, solo, dankort, maestro, forbrugsforeningen, laser
 }
 
+// PaymentProfileUpdate holds the fields to change on a payment profile. Only the fields that are set are sent to Chargify,
+// so fields that are left nil keep their current value.
+type PaymentProfileUpdate struct {
+	FirstName       *string      `json:"first_name,omitempty"`        // First name on card or bank account
+	LastName        *string      `json:"last_name,omitempty"`         // Last name on card or bank account
+	FullNumber      *string      `json:"full_number,omitempty"`       // The full credit card number
+	CardType        *string      `json:"card_type,omitempty"`         // The type of card, such as visa or master
+	ExpirationMonth *string      `json:"expiration_month,omitempty"`  // The 1- or 2-digit credit card expiration month
+	ExpirationYear  *string      `json:"expiration_year,omitempty"`   // The 4-digit credit card expiration year
+	CurrentVault    *VaultMethod `json:"current_vault,omitempty"`     // The vault that stores the card details
+	BillingAddress  *string      `json:"billing_address,omitempty"`   // The billing street address
+	BillingAddress2 *string      `json:"billing_address_2,omitempty"` // Second line of the billing address
+	BillingCity     *string      `json:"billing_city,omitempty"`      // The billing address city
+	BillingState    *string      `json:"billing_state,omitempty"`     // The billing address state
+	BillingZip      *string      `json:"billing_zip,omitempty"`       // The billing address zip code
+	BillingCountry  *string      `json:"billing_country,omitempty"`   // The billing address country
+}
+
 // VaultMethod represents one of the payment vaults for use with tokenization. This is generally the recommended way to handle payment methods.
 type VaultMethod string
 
@@ -183,7 +201,8 @@ func DeletePaymentProfile(subscriptionID int64, profileID int64) error {
 	return nil
 }
 
-// UpdatePaymentProfile updates a payment profile
+// UpdatePaymentProfile updates a payment profile. Note that every field is sent, so empty fields on the input will clear
+// the values on Chargify; use UpdatePaymentProfileFields to only change specific fields.
 func UpdatePaymentProfile(input *PaymentProfile) error {
 	body := map[string]PaymentProfile{
 		"payment_profile": *input,
@@ -206,3 +225,31 @@ func UpdatePaymentProfile(input *PaymentProfile) error {
 	err = mapstructure.Decode(apiBody["payment_profile"], input)
 	return err
 }
+
+// UpdatePaymentProfileFields updates only the fields that are set on the input and returns the updated payment profile
+func UpdatePaymentProfileFields(paymentProfileID int64, input *PaymentProfileUpdate) (*PaymentProfile, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointPaymentProfileUpdate],
+		PathParams: &map[string]string{
+			"paymentProfileID": fmt.Sprintf("%d", paymentProfileID),
+		},
+		Body: map[string]PaymentProfileUpdate{
+			"payment_profile": *input,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	// if successful, the profile should come back in a map[payment_profile]PaymentProfile format
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	profile := &PaymentProfile{}
+	err = mapstructure.Decode(apiBody["payment_profile"], profile)
+	return profile, err
+}
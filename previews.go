@@ -0,0 +1,121 @@
+package chargify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// PreviewLineItem is a single line item of a renewal or signup preview. All amounts are in integer cents.
+type PreviewLineItem struct {
+	TransactionType       string `json:"transaction_type" mapstructure:"transaction_type"`                 // The type of transaction, such as charge or credit
+	Kind                  string `json:"kind" mapstructure:"kind"`                                         // What the line item is for, such as baseline, initial, trial, component, or coupon
+	AmountInCents         int64  `json:"amount_in_cents" mapstructure:"amount_in_cents"`                   // The amount of the line item
+	Memo                  string `json:"memo" mapstructure:"memo"`                                         // A description of the line item
+	DiscountAmountInCents int64  `json:"discount_amount_in_cents" mapstructure:"discount_amount_in_cents"` // The discount applied to the line item
+	TaxableAmountInCents  int64  `json:"taxable_amount_in_cents" mapstructure:"taxable_amount_in_cents"`   // The portion of the line item that is taxable
+	ProductID             int64  `json:"product_id" mapstructure:"product_id"`
+	ProductHandle         string `json:"product_handle" mapstructure:"product_handle"`
+	ProductName           string `json:"product_name" mapstructure:"product_name"`
+	ComponentID           int64  `json:"component_id" mapstructure:"component_id"`
+	ComponentHandle       string `json:"component_handle" mapstructure:"component_handle"`
+	ComponentName         string `json:"component_name" mapstructure:"component_name"`
+	PeriodRangeStart      string `json:"period_range_start" mapstructure:"period_range_start"` // The start of the period the line item covers
+	PeriodRangeEnd        string `json:"period_range_end" mapstructure:"period_range_end"`     // The end of the period the line item covers
+}
+
+// RenewalPreview is the preview of the next renewal of a subscription. All amounts are in integer cents.
+type RenewalPreview struct {
+	NextAssessmentAt       string            `json:"next_assessment_at" mapstructure:"next_assessment_at"`               // When the renewal will happen
+	SubtotalInCents        int64             `json:"subtotal_in_cents" mapstructure:"subtotal_in_cents"`                 // The total before discounts and taxes
+	TotalTaxInCents        int64             `json:"total_tax_in_cents" mapstructure:"total_tax_in_cents"`               // The total of the taxes
+	TotalDiscountInCents   int64             `json:"total_discount_in_cents" mapstructure:"total_discount_in_cents"`     // The total of the discounts
+	TotalInCents           int64             `json:"total_in_cents" mapstructure:"total_in_cents"`                       // The total of the renewal
+	ExistingBalanceInCents int64             `json:"existing_balance_in_cents" mapstructure:"existing_balance_in_cents"` // The balance already on the subscription
+	TotalAmountDueInCents  int64             `json:"total_amount_due_in_cents" mapstructure:"total_amount_due_in_cents"` // The total plus the existing balance
+	UncalculatedTaxes      bool              `json:"uncalculated_taxes" mapstructure:"uncalculated_taxes"`               // True if the taxes could not be calculated
+	LineItems              []PreviewLineItem `json:"line_items" mapstructure:"line_items"`
+}
+
+// RenewalPreviewComponent is a hypothetical component quantity to use when previewing a renewal
+type RenewalPreviewComponent struct {
+	ComponentID  int64  `json:"component_id"`
+	Quantity     int64  `json:"quantity"`
+	PricePointID *int64 `json:"price_point_id,omitempty"` // (Optional) The price point to use instead of the current one
+}
+
+// BillingManifest is the breakdown of the charges for a single billing period of a signup preview. All amounts are in
+// integer cents.
+type BillingManifest struct {
+	StartDate              string            `json:"start_date" mapstructure:"start_date"`                               // The start of the billing period
+	EndDate                string            `json:"end_date" mapstructure:"end_date"`                                   // The end of the billing period
+	PeriodType             string            `json:"period_type" mapstructure:"period_type"`                             // The type of the period, such as trial or recurring
+	SubtotalInCents        int64             `json:"subtotal_in_cents" mapstructure:"subtotal_in_cents"`                 // The total before discounts and taxes
+	TotalTaxInCents        int64             `json:"total_tax_in_cents" mapstructure:"total_tax_in_cents"`               // The total of the taxes
+	TotalDiscountInCents   int64             `json:"total_discount_in_cents" mapstructure:"total_discount_in_cents"`     // The total of the discounts
+	TotalInCents           int64             `json:"total_in_cents" mapstructure:"total_in_cents"`                       // The total of the period
+	ExistingBalanceInCents int64             `json:"existing_balance_in_cents" mapstructure:"existing_balance_in_cents"` // The balance already owed
+	LineItems              []PreviewLineItem `json:"line_items" mapstructure:"line_items"`
+}
+
+// SignupPreview is the preview of the charges for a subscription that has not been created yet
+type SignupPreview struct {
+	CurrentBillingManifest *BillingManifest `json:"current_billing_manifest" mapstructure:"current_billing_manifest"` // The charges at signup
+	NextBillingManifest    *BillingManifest `json:"next_billing_manifest" mapstructure:"next_billing_manifest"`       // The charges at the first renewal
+}
+
+// PreviewSubscriptionRenewal previews the next renewal of a subscription. The components are optional and let you see the
+// renewal as if the subscription had different component quantities.
+func PreviewSubscriptionRenewal(subscriptionID int64, components []RenewalPreviewComponent) (*RenewalPreview, error) {
+	body := map[string]interface{}{}
+	if len(components) > 0 {
+		body["components"] = components
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionRenewalPreview],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: body,
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	preview := &RenewalPreview{}
+	err = mapstructure.Decode(apiBody["renewal_preview"], preview)
+	return preview, err
+}
+
+// PreviewSubscriptionSignup previews the charges of creating a subscription, without creating it. The input is the same as
+// CreateSubscription, except that the customer is optional.
+func PreviewSubscriptionSignup(input *CreateSubscriptionInput) (*SignupPreview, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if err := input.validate(false); err != nil {
+		return nil, err
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionSignupPreview],
+		Body: map[string]CreateSubscriptionInput{
+			"subscription": *input,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	preview := &SignupPreview{}
+	err = mapstructure.Decode(apiBody["subscription_preview"], preview)
+	return preview, err
+}
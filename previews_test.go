@@ -0,0 +1,43 @@
+package chargify
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenewalPreviewDecode(t *testing.T) {
+	// the numbers come back from the API as float64, so decode through a generic map like the request helpers do
+	raw := map[string]interface{}{}
+	err := json.Unmarshal([]byte(`{
+		"next_assessment_at": "2030-01-01T00:00:00-05:00",
+		"subtotal_in_cents": 6000,
+		"total_tax_in_cents": 0,
+		"total_discount_in_cents": 1000,
+		"total_in_cents": 5000,
+		"existing_balance_in_cents": 0,
+		"total_amount_due_in_cents": 5000,
+		"uncalculated_taxes": false,
+		"line_items": [
+			{"transaction_type": "charge", "kind": "baseline", "amount_in_cents": 5000, "memo": "Gold", "product_id": 1},
+			{"transaction_type": "charge", "kind": "component", "amount_in_cents": 1000, "memo": "Seats", "component_id": 2}
+		]
+	}`), &raw)
+	require.Nil(t, err)
+	preview := &RenewalPreview{}
+	require.Nil(t, mapstructure.Decode(raw, preview))
+	assert.Equal(t, int64(5000), preview.TotalAmountDueInCents)
+	require.Equal(t, 2, len(preview.LineItems))
+	assert.Equal(t, "baseline", preview.LineItems[0].Kind)
+	assert.Equal(t, int64(2), preview.LineItems[1].ComponentID)
+}
+
+func TestPreviewSubscriptionSignupValidation(t *testing.T) {
+	_, err := PreviewSubscriptionSignup(nil)
+	assert.NotNil(t, err)
+	_, err = PreviewSubscriptionSignup(&CreateSubscriptionInput{})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,193 @@
+package chargify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// ProductPricePoint is an alternate price for a product, such as an annual or promotional price. A subscription can be
+// created with or migrated to a price point by passing its id or handle.
+type ProductPricePoint struct {
+	ID                      int64           `json:"id,omitempty" mapstructure:"id"`
+	ProductID               int64           `json:"product_id,omitempty" mapstructure:"product_id"`
+	Name                    string          `json:"name" mapstructure:"name"`                                                       // The price point name
+	Handle                  string          `json:"handle,omitempty" mapstructure:"handle"`                                         // The price point API handle
+	PriceInCents            int64           `json:"price_in_cents" mapstructure:"price_in_cents"`                                   // The price, in integer cents
+	Interval                int             `json:"interval" mapstructure:"interval"`                                               // The numerical interval, coupled with the interval unit
+	IntervalUnit            ProductInterval `json:"interval_unit" mapstructure:"interval_unit"`                                     // The interval unit, either month or day
+	TrialPriceInCents       *int64          `json:"trial_price_in_cents,omitempty" mapstructure:"trial_price_in_cents"`             // The price of the trial period, in integer cents
+	TrialInterval           *int            `json:"trial_interval,omitempty" mapstructure:"trial_interval"`                         // The numerical interval for the length of the trial period
+	TrialIntervalUnit       ProductInterval `json:"trial_interval_unit,omitempty" mapstructure:"trial_interval_unit"`               // The trial interval unit, either month or day
+	TrialType               string          `json:"trial_type,omitempty" mapstructure:"trial_type"`                                 // Either payment_expected or no_obligation
+	InitialChargeInCents    *int64          `json:"initial_charge_in_cents,omitempty" mapstructure:"initial_charge_in_cents"`       // The up front charge, in integer cents
+	InitialChargeAfterTrial *bool           `json:"initial_charge_after_trial,omitempty" mapstructure:"initial_charge_after_trial"` // Whether the initial charge is made when the trial ends instead of at signup
+	ExpirationInterval      *int            `json:"expiration_interval,omitempty" mapstructure:"expiration_interval"`               // The numerical interval a subscription runs before it expires
+	ExpirationIntervalUnit  ProductInterval `json:"expiration_interval_unit,omitempty" mapstructure:"expiration_interval_unit"`     // The expiration interval unit, either month or day
+	Type                    string          `json:"type,omitempty" mapstructure:"type"`                                             // Either catalog, default, or custom; only read
+	ArchivedAt              string          `json:"archived_at,omitempty" mapstructure:"archived_at"`
+	CreatedAt               string          `json:"created_at,omitempty" mapstructure:"created_at"`
+	UpdatedAt               string          `json:"updated_at,omitempty" mapstructure:"updated_at"`
+}
+
+// ProductPricePointUpdate holds the fields to change on a product price point. Only the fields that are set are sent to
+// Chargify, so fields that are left nil keep their current value.
+type ProductPricePointUpdate struct {
+	Name                    *string          `json:"name,omitempty"`
+	Handle                  *string          `json:"handle,omitempty"`
+	PriceInCents            *int64           `json:"price_in_cents,omitempty"`
+	Interval                *int             `json:"interval,omitempty"`
+	IntervalUnit            *ProductInterval `json:"interval_unit,omitempty"`
+	TrialPriceInCents       *int64           `json:"trial_price_in_cents,omitempty"`
+	TrialInterval           *int             `json:"trial_interval,omitempty"`
+	TrialIntervalUnit       *ProductInterval `json:"trial_interval_unit,omitempty"`
+	TrialType               *string          `json:"trial_type,omitempty"`
+	InitialChargeInCents    *int64           `json:"initial_charge_in_cents,omitempty"`
+	InitialChargeAfterTrial *bool            `json:"initial_charge_after_trial,omitempty"`
+	ExpirationInterval      *int             `json:"expiration_interval,omitempty"`
+	ExpirationIntervalUnit  *ProductInterval `json:"expiration_interval_unit,omitempty"`
+}
+
+func (input *ProductPricePoint) validate() error {
+	if input.Name == "" {
+		return errors.New("name is required")
+	}
+	if input.PriceInCents < 0 {
+		return errors.New("price in cents cannot be negative")
+	}
+	if input.IntervalUnit == "" || input.Interval <= 0 {
+		return errors.New("interval and interval unit must be provided")
+	}
+	if (input.TrialInterval != nil) != (input.TrialIntervalUnit != "") {
+		return errors.New("trial interval and trial interval unit must be provided together")
+	}
+	if (input.ExpirationInterval != nil) != (input.ExpirationIntervalUnit != "") {
+		return errors.New("expiration interval and expiration interval unit must be provided together")
+	}
+	return nil
+}
+
+// ListProductPricePoints lists the price points of a product, including the default price point
+func ListProductPricePoints(productID int64, page int, perPage int) ([]ProductPricePoint, error) {
+	if page < 1 {
+		return nil, errors.New("page must be 1 or higher, not 0 indexed")
+	}
+	queryParams := map[string]string{
+		"page": fmt.Sprintf("%d", page),
+	}
+	if perPage > 0 {
+		queryParams["per_page"] = fmt.Sprintf("%d", perPage)
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointProductPricePointsList],
+		PathParams: &map[string]string{
+			"productID": fmt.Sprintf("%d", productID),
+		},
+		QueryParams: &queryParams,
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	pricePoints := []ProductPricePoint{}
+	err = mapstructure.Decode(apiBody["price_points"], &pricePoints)
+	return pricePoints, err
+}
+
+// GetProductPricePoint gets a single price point of a product
+func GetProductPricePoint(productID int64, pricePointID int64) (*ProductPricePoint, error) {
+	return saveProductPricePoint(endpointProductPricePointGet, productID, pricePointID, nil)
+}
+
+// CreateProductPricePoint creates a new price point on a product. The result is placed in the input.
+func CreateProductPricePoint(productID int64, input *ProductPricePoint) error {
+	if input == nil {
+		return errors.New("input is required")
+	}
+	if err := input.validate(); err != nil {
+		return err
+	}
+	pricePoint, err := saveProductPricePoint(endpointProductPricePointCreate, productID, 0, map[string]ProductPricePoint{
+		"price_point": *input,
+	})
+	if err != nil {
+		return err
+	}
+	*input = *pricePoint
+	return nil
+}
+
+// UpdateProductPricePoint updates only the fields that are set on the input and returns the updated price point
+func UpdateProductPricePoint(productID int64, pricePointID int64, input *ProductPricePointUpdate) (*ProductPricePoint, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	return saveProductPricePoint(endpointProductPricePointUpdate, productID, pricePointID, map[string]ProductPricePointUpdate{
+		"price_point": *input,
+	})
+}
+
+// ArchiveProductPricePoint archives a price point so no new subscriptions can use it. Existing subscriptions on the
+// price point are not changed. The default price point cannot be archived.
+func ArchiveProductPricePoint(productID int64, pricePointID int64) (*ProductPricePoint, error) {
+	return saveProductPricePoint(endpointProductPricePointArchive, productID, pricePointID, nil)
+}
+
+// UnarchiveProductPricePoint restores an archived price point
+func UnarchiveProductPricePoint(productID int64, pricePointID int64) (*ProductPricePoint, error) {
+	return saveProductPricePoint(endpointProductPricePointUnarchive, productID, pricePointID, nil)
+}
+
+// SetDefaultProductPricePoint makes the price point the default for the product, which is used when a subscription is
+// created without a price point, and returns the updated product
+func SetDefaultProductPricePoint(productID int64, pricePointID int64) (*Product, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointProductPricePointSetDefault],
+		PathParams: &map[string]string{
+			"productID":    fmt.Sprintf("%d", productID),
+			"pricePointID": fmt.Sprintf("%d", pricePointID),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	product := &Product{}
+	err = mapstructure.Decode(apiBody["product"], product)
+	return product, err
+}
+
+// saveProductPricePoint makes a call against a single price point and decodes the price point that comes back
+func saveProductPricePoint(endpointName string, productID int64, pricePointID int64, body interface{}) (*ProductPricePoint, error) {
+	pathParams := map[string]string{
+		"productID": fmt.Sprintf("%d", productID),
+	}
+	if pricePointID != 0 {
+		pathParams["pricePointID"] = fmt.Sprintf("%d", pricePointID)
+	}
+	options := &makeCallOptions{
+		End:        endpoints[endpointName],
+		PathParams: &pathParams,
+		Body:       body,
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	pricePoint := &ProductPricePoint{}
+	err = mapstructure.Decode(apiBody["price_point"], pricePoint)
+	return pricePoint, err
+}
@@ -0,0 +1,103 @@
+package chargify
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductPricePointCRUD(t *testing.T) {
+	customer, _, err := createTestCustomer()
+	require.Nil(t, err)
+	_, product, err := createTestProductAndFamily()
+	require.Nil(t, err)
+
+	pricePoint := &ProductPricePoint{
+		Name:         "Annual",
+		Handle:       fmt.Sprintf("annual-%d", rand.Int63()),
+		PriceInCents: 10000,
+		Interval:     12,
+		IntervalUnit: ProductIntervalMonth,
+	}
+	err = CreateProductPricePoint(product.ID, pricePoint)
+	require.Nil(t, err)
+	require.NotZero(t, pricePoint.ID)
+	assert.Equal(t, product.ID, pricePoint.ProductID)
+
+	price := int64(9000)
+	updated, err := UpdateProductPricePoint(product.ID, pricePoint.ID, &ProductPricePointUpdate{
+		PriceInCents: &price,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, price, updated.PriceInCents)
+
+	found, err := GetProductPricePoint(product.ID, pricePoint.ID)
+	require.Nil(t, err)
+	assert.Equal(t, pricePoint.Handle, found.Handle)
+
+	pricePoints, err := ListProductPricePoints(product.ID, 1, 0)
+	require.Nil(t, err)
+	assert.True(t, len(pricePoints) >= 2)
+
+	subscription, err := CreateSubscription(&CreateSubscriptionInput{
+		CustomerReference:       customer.Reference,
+		ProductHandle:           product.Handle,
+		ProductPricePointHandle: pricePoint.Handle,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, pricePoint.ID, subscription.ProductPricePointID)
+
+	_, err = ArchiveProductPricePoint(product.ID, pricePoint.ID)
+	require.Nil(t, err)
+	unarchived, err := UnarchiveProductPricePoint(product.ID, pricePoint.ID)
+	require.Nil(t, err)
+	assert.Empty(t, unarchived.ArchivedAt)
+
+	withDefault, err := SetDefaultProductPricePoint(product.ID, pricePoint.ID)
+	require.Nil(t, err)
+	assert.Equal(t, product.ID, withDefault.ID)
+	assert.Equal(t, pricePoint.ID, withDefault.DefaultProductPricePointID)
+	foundProduct, err := GetProductByID(product.ID)
+	require.Nil(t, err)
+	assert.Equal(t, pricePoint.ID, foundProduct.DefaultProductPricePointID)
+
+	err = CancelSubscription(subscription.ID, true, "", "Testing")
+	assert.Nil(t, err)
+}
+
+func TestProductPricePointInput(t *testing.T) {
+	assert.NotNil(t, CreateProductPricePoint(1, nil))
+	_, err := UpdateProductPricePoint(1, 1, nil)
+	assert.NotNil(t, err)
+
+	input := &ProductPricePoint{}
+	assert.NotNil(t, input.validate())
+	input.Name = "Annual"
+	assert.NotNil(t, input.validate())
+	input.Interval = 12
+	input.IntervalUnit = ProductIntervalMonth
+	assert.Nil(t, input.validate())
+	input.PriceInCents = -1
+	assert.NotNil(t, input.validate())
+	input.PriceInCents = 0
+	trial := 14
+	input.TrialInterval = &trial
+	assert.NotNil(t, input.validate())
+	input.TrialIntervalUnit = ProductIntervalDay
+	assert.Nil(t, input.validate())
+	input.ExpirationIntervalUnit = ProductIntervalMonth
+	assert.NotNil(t, input.validate())
+
+	// a free price point still sends its price, while an update only sends what is set
+	encoded, err := json.Marshal(&ProductPricePoint{Name: "Free", Interval: 1, IntervalUnit: ProductIntervalMonth})
+	require.Nil(t, err)
+	assert.Equal(t, `{"name":"Free","price_in_cents":0,"interval":1,"interval_unit":"month"}`, string(encoded))
+	name := "Monthly"
+	encoded, err = json.Marshal(&ProductPricePointUpdate{Name: &name})
+	require.Nil(t, err)
+	assert.Equal(t, `{"name":"Monthly"}`, string(encoded))
+}
@@ -50,14 +50,16 @@ type ProductFamilyComponent struct {
 	AllowFractionalQuantities bool    `json:"allow_fractional_quantities" mapstructure:"allow_fractional_quantities"`
 }
 
+// Price is a single price within a pricing scheme. A per unit scheme has one price; the volume, tiered, and stairstep
+// schemes have one price per tier, where the last tier leaves EndingQuantity at 0 to run without an upper bound.
 type Price struct {
-	ID                 int64  `json:"id"`
-	ComponentID        int64  `json:"component_id" mapstructure:"component_id"`
-	StartingQuantity   int64  `json:"starting_quantity" mapstructure:"starting_quantity"`
-	EndingQuantity     int64  `json:"ending_quantity" mapstructure:"ending_quantity"`
-	UnitPrice          string `json:"unit_price" mapstructure:"unit_price"`
-	PricePointID       int64  `json:"price_point_id" mapstructure:"price_point_id"`
-	FormattedUnitPrice string `json:"formatted_unit_price" mapstructure:"formatted_unit_price"`
+	ID                 int64  `json:"id,omitempty"`
+	ComponentID        int64  `json:"component_id,omitempty" mapstructure:"component_id"`
+	StartingQuantity   int64  `json:"starting_quantity,omitempty" mapstructure:"starting_quantity"`
+	EndingQuantity     int64  `json:"ending_quantity,omitempty" mapstructure:"ending_quantity"`
+	UnitPrice          string `json:"unit_price,omitempty" mapstructure:"unit_price"`
+	PricePointID       int64  `json:"price_point_id,omitempty" mapstructure:"price_point_id"`
+	FormattedUnitPrice string `json:"formatted_unit_price,omitempty" mapstructure:"formatted_unit_price"`
 }
 
 // Product represents a single product
@@ -87,7 +89,31 @@ type Product struct {
 	SignupPages             *[]SignupPage   `json:"public_signup_pages" mapstructure:"public_signup_pages"`             // An array of signup pages
 	AutoCreateSignupPage    bool            `json:"auto_create_signup_page" mapstructure:"auto_create_signup_page"`     // Whether or not to create a signup page
 	TaxCode                 string          `json:"tax_code" mapstructure:"tax_code"`                                   // A string representing the tax code related to the product type. This is especially important when using the Avalara service to tax based on locale. This attribute has a max length of 10 characters.
+	// only read; change it with SetDefaultProductPricePoint
+	DefaultProductPricePointID int64 `json:"default_product_price_point_id,omitempty" mapstructure:"default_product_price_point_id"` // The price point used when a subscription does not pick one
+}
 
+// ProductUpdate holds the fields to change on a product. Only the fields that are set are sent to Chargify, so fields
+// that are left nil keep their current value.
+type ProductUpdate struct {
+	PriceInCents            *int             `json:"price_in_cents,omitempty"`           // The product price, in integer cents
+	Name                    *string          `json:"name,omitempty"`                     // The product name
+	Handle                  *string          `json:"handle,omitempty"`                   // The product API handle
+	Description             *string          `json:"description,omitempty"`              // The product description
+	IntervalUnit            *ProductInterval `json:"interval_unit,omitempty"`            // The interval unit for this product, either month or day
+	IntervalValue           *int             `json:"interval,omitempty"`                 // The numerical interval
+	InitialChargeInCents    *int             `json:"initial_charge_in_cents,omitempty"`  // The up front charge
+	TrialPriceInCents       *int             `json:"trial_price_in_cents,omitempty"`     // The price of the trial period, in integer cents
+	TrialIntervalValue      *int             `json:"trial_interval,omitempty"`           // The numerical interval for the length of the trial period
+	TrialIntervalUnit       *ProductInterval `json:"trial_interval_unit,omitempty"`      // The trial interval unit, either month or day
+	ExpirationIntervalValue *int             `json:"expiration_interval,omitempty"`      // The numerical interval a subscription runs before it expires
+	ExpirationIntervalUnit  *ProductInterval `json:"expiration_interval_unit,omitempty"` // The expiration interval unit, either month or day
+	UpdateReturnURL         *string          `json:"update_return_url,omitempty"`        // The url a customer is returned to after a successful account update
+	UpdateReturnParams      *string          `json:"update_return_params,omitempty"`     // The parameters appended to the return url
+	RequireCreditCard       *bool            `json:"require_credit_card,omitempty"`      // Whether a credit card is required
+	RequestCreditCard       *bool            `json:"request_credit_card,omitempty"`      // Whether a credit card is requested
+	AutoCreateSignupPage    *bool            `json:"auto_create_signup_page,omitempty"`  // Whether or not to create a signup page
+	TaxCode                 *string          `json:"tax_code,omitempty"`                 // The tax code related to the product type
 }
 
 // SignupPage represents a product's signup page, if needed
@@ -362,18 +388,46 @@ func GetProductByHandle(handle string) (*Product, error) {
 	return product, err
 }
 
-// UpdateProduct updates a product
+// UpdateProduct updates a product. Note that every field is sent, so empty fields on the input will clear the values on
+// Chargify; use UpdateProductFields to only change specific fields.
 func UpdateProduct(productID int64, input *Product) error {
 	body := map[string]Product{
 		"product": *input,
 	}
 
 	_, err := makeCall(endpoints[endpointProductUpdate], body, &map[string]string{
-		"productID": fmt.Sprintf("%d", productID),
+		"id": fmt.Sprintf("%d", productID),
 	})
 	return err
 }
 
+// UpdateProductFields updates only the fields that are set on the input and returns the updated product
+func UpdateProductFields(productID int64, input *ProductUpdate) (*Product, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointProductUpdate],
+		PathParams: &map[string]string{
+			"id": fmt.Sprintf("%d", productID),
+		},
+		Body: map[string]ProductUpdate{
+			"product": *input,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	product := &Product{}
+	err = mapstructure.Decode(apiBody["product"], product)
+	return product, err
+}
+
 // ArchiveProduct archives a product
 func ArchiveProduct(productID int64) error {
 	_, err := makeCall(endpoints[endpointProductArchive], nil, &map[string]string{
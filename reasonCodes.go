@@ -0,0 +1,175 @@
+package chargify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// ErrInvalidReasonCode is returned when a reason code is not one of the reason codes configured for the site
+var ErrInvalidReasonCode = errors.New("reason code is not configured for the site")
+
+// ReasonCode is a code that explains why a subscription was canceled, configured for the site
+type ReasonCode struct {
+	ID          int64  `json:"id,omitempty" mapstructure:"id"`
+	SiteID      int64  `json:"site_id,omitempty" mapstructure:"site_id"`
+	Code        string `json:"code" mapstructure:"code"`                   // The code sent when canceling a subscription
+	Description string `json:"description" mapstructure:"description"`     // A description of the reason
+	Position    int64  `json:"position,omitempty" mapstructure:"position"` // The order the code is shown in, starting at 1
+	CreatedAt   string `json:"created_at,omitempty" mapstructure:"created_at"`
+	UpdatedAt   string `json:"updated_at,omitempty" mapstructure:"updated_at"`
+}
+
+// ListReasonCodes lists the reason codes configured for the site
+func ListReasonCodes(page int, perPage int) ([]ReasonCode, error) {
+	if page < 1 {
+		return nil, errors.New("page must be 1 or higher, not 0 indexed")
+	}
+	queryParams := map[string]string{
+		"page": fmt.Sprintf("%d", page),
+	}
+	if perPage > 0 {
+		queryParams["per_page"] = fmt.Sprintf("%d", perPage)
+	}
+	options := &makeCallOptions{
+		End:         endpoints[endpointReasonCodesList],
+		QueryParams: &queryParams,
+	}
+
+	data := []ReasonCode{}
+
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return data, err
+	}
+	apiBody, bodyOK := ret.Body.([]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	// the result is an array of objects that have a reason_code key
+	for i := range apiBody {
+		if raw, rawOK := apiBody[i].(map[string]interface{}); rawOK {
+			code := ReasonCode{}
+			err = mapstructure.Decode(raw["reason_code"], &code)
+			if err == nil {
+				data = append(data, code)
+			}
+		}
+	}
+	return data, nil
+}
+
+// GetReasonCode gets a single reason code by its id
+func GetReasonCode(reasonCodeID int64) (*ReasonCode, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointReasonCodeGet],
+		PathParams: &map[string]string{
+			"reasonCodeID": fmt.Sprintf("%d", reasonCodeID),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	code := &ReasonCode{}
+	err = decodeReasonCode(ret, code)
+	return code, err
+}
+
+// CreateReasonCode creates a new reason code. The result is placed in the input.
+func CreateReasonCode(input *ReasonCode) error {
+	if input.Code == "" || input.Description == "" {
+		return errors.New("code and description are required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointReasonCodeCreate],
+		Body: map[string]map[string]interface{}{
+			"reason_code": input.toBody(),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return err
+	}
+	return decodeReasonCode(ret, input)
+}
+
+// UpdateReasonCode updates the code, description, and position of a reason code. Empty values are not sent. The result is
+// placed in the input.
+func UpdateReasonCode(input *ReasonCode) error {
+	if input.ID == 0 {
+		return errors.New("id is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointReasonCodeUpdate],
+		PathParams: &map[string]string{
+			"reasonCodeID": fmt.Sprintf("%d", input.ID),
+		},
+		Body: map[string]map[string]interface{}{
+			"reason_code": input.toBody(),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return err
+	}
+	return decodeReasonCode(ret, input)
+}
+
+// DeleteReasonCode deletes a reason code. Subscriptions that were canceled with it keep the code.
+func DeleteReasonCode(reasonCodeID int64) error {
+	options := &makeCallOptions{
+		End: endpoints[endpointReasonCodeDelete],
+		PathParams: &map[string]string{
+			"reasonCodeID": fmt.Sprintf("%d", reasonCodeID),
+		},
+	}
+	_, err := makeAPICall(options)
+	return err
+}
+
+// ValidateReasonCode checks that the code is one of the reason codes configured for the site, returning
+// ErrInvalidReasonCode if it is not
+func ValidateReasonCode(code string) error {
+	if code == "" {
+		return ErrInvalidReasonCode
+	}
+	// sites rarely have more than a handful, but page through them all to be sure
+	for page := 1; ; page++ {
+		codes, err := ListReasonCodes(page, 200)
+		if err != nil {
+			return err
+		}
+		for i := range codes {
+			if codes[i].Code == code {
+				return nil
+			}
+		}
+		if len(codes) < 200 {
+			return ErrInvalidReasonCode
+		}
+	}
+}
+
+func (input *ReasonCode) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if input.Code != "" {
+		body["code"] = input.Code
+	}
+	if input.Description != "" {
+		body["description"] = input.Description
+	}
+	if input.Position != 0 {
+		body["position"] = input.Position
+	}
+	return body
+}
+
+func decodeReasonCode(ret APIReturn, code *ReasonCode) error {
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return errors.New("could not understand server response")
+	}
+	return mapstructure.Decode(apiBody["reason_code"], code)
+}
@@ -0,0 +1,51 @@
+package chargify
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReasonCodeBody(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{}, (&ReasonCode{}).toBody())
+	assert.Equal(t, map[string]interface{}{
+		"code":        "PRICE",
+		"description": "Too expensive",
+		"position":    int64(2),
+	}, (&ReasonCode{ID: 1, Code: "PRICE", Description: "Too expensive", Position: 2}).toBody())
+
+	assert.True(t, errors.Is(ValidateReasonCode(""), ErrInvalidReasonCode))
+	err := CancelSubscriptionWithOptions(1, &CancelSubscriptionOptions{ValidateReasonCode: true})
+	assert.True(t, errors.Is(err, ErrInvalidReasonCode))
+}
+
+func TestReasonCodeCRUD(t *testing.T) {
+	code := &ReasonCode{
+		Code:        fmt.Sprintf("TEST%d", rand.Int63n(999999)),
+		Description: "Testing",
+	}
+	err := CreateReasonCode(code)
+	require.Nil(t, err)
+	require.NotZero(t, code.ID)
+	defer DeleteReasonCode(code.ID)
+
+	found, err := GetReasonCode(code.ID)
+	require.Nil(t, err)
+	assert.Equal(t, code.Code, found.Code)
+
+	code.Description = "Updated"
+	err = UpdateReasonCode(code)
+	require.Nil(t, err)
+	assert.Equal(t, "Updated", code.Description)
+
+	assert.Nil(t, ValidateReasonCode(code.Code))
+	assert.True(t, errors.Is(ValidateReasonCode(code.Code+"MISSING"), ErrInvalidReasonCode))
+
+	codes, err := ListReasonCodes(1, 200)
+	require.Nil(t, err)
+	assert.NotEmpty(t, codes)
+}
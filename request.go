@@ -7,6 +7,7 @@ import (
 	"net/http"
 	nurl "net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -21,6 +22,31 @@ type APIReturn struct {
 	Body       interface{} `json:"body"`
 }
 
+// ErrNotFound is returned when Chargify responds that the requested entity does not exist
+var ErrNotFound = errors.New("not found")
+
+// ValidationError is returned when Chargify rejects a request as unprocessable, such as when a field is invalid or a
+// unique value has already been taken. Errors holds each message that Chargify sent back.
+type ValidationError struct {
+	Errors []string
+}
+
+// Error joins the messages into a single string
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Errors, " ")
+}
+
+// Contains reports whether any of the messages contain the passed in text, ignoring case
+func (e *ValidationError) Contains(text string) bool {
+	text = strings.ToLower(text)
+	for i := range e.Errors {
+		if strings.Contains(strings.ToLower(e.Errors[i]), text) {
+			return true
+		}
+	}
+	return false
+}
+
 // makeCallOptions is an internal struct allowing for specifying the needed values for the API calls
 type makeCallOptions struct {
 	End              endpoint
@@ -127,7 +153,13 @@ func executeAPICall(options *makeCallOptions) (ret APIReturn, err error) {
 		response, err = httpRequest.SetBody(body).Post(url)
 	} else if end.method == http.MethodPut {
 		response, err = httpRequest.SetBody(body).Put(url)
+	} else if end.method == http.MethodPatch {
+		response, err = httpRequest.SetBody(body).Patch(url)
 	} else if end.method == http.MethodDelete {
+		// most deletes have no body, but some, such as canceling a subscription, accept one
+		if body != nil {
+			httpRequest.SetBody(body)
+		}
 		response, err = httpRequest.Delete(url)
 	}
 
@@ -143,7 +175,7 @@ func executeAPICall(options *makeCallOptions) (ret APIReturn, err error) {
 	case http.StatusForbidden, http.StatusUnauthorized:
 		err = errors.New("permission denied")
 	case http.StatusNotFound:
-		err = errors.New("not found")
+		err = ErrNotFound
 	case http.StatusInternalServerError:
 		err = errors.New("chargify server error")
 	case http.StatusOK, http.StatusCreated:
@@ -218,22 +250,38 @@ func convertStructToMap(i interface{}) (result map[string]string) {
 func apiErrorToError(input interface{}) error {
 	// the body is likely a map of errors to []string
 	// sometimes it is just a map of errors to a single string
+	// and sometimes it is a map of errors to a map of fields to []string
 	// which is pretty frustrating
-	finalError := ""
-
-	if errsI, errsOK := input.(map[string]interface{}); errsOK {
-		if errs, errsIsSlice := errsI["errors"].([]interface{}); errsIsSlice {
-			errorStrings := make([]string, len(errs))
-			for i := range errs {
-				errorStrings = append(errorStrings, errs[i].(string))
+	errsI, errsOK := input.(map[string]interface{})
+	if !errsOK {
+		return errors.New("error not found or not valid")
+	}
+	validationErr := &ValidationError{
+		Errors: []string{},
+	}
+	switch errs := errsI["errors"].(type) {
+	case []interface{}:
+		for i := range errs {
+			if e, eOK := errs[i].(string); eOK {
+				validationErr.Errors = append(validationErr.Errors, e)
+			}
+		}
+	case string:
+		validationErr.Errors = append(validationErr.Errors, errs)
+	case map[string]interface{}:
+		for field, fieldErrs := range errs {
+			switch fieldErr := fieldErrs.(type) {
+			case []interface{}:
+				for i := range fieldErr {
+					validationErr.Errors = append(validationErr.Errors, fmt.Sprintf("%s: %v", field, fieldErr[i]))
+				}
+			default:
+				validationErr.Errors = append(validationErr.Errors, fmt.Sprintf("%s: %v", field, fieldErr))
 			}
-			finalError = strings.Join(errorStrings, " ")
-		} else if err, errIsString := errsI["errors"].(string); errIsString {
-			finalError = err
 		}
-		return errors.New(finalError)
+		sort.Strings(validationErr.Errors)
 	}
-	return errors.New("error not found or not valid")
+	return validationErr
 }
 
 // ConvertJSONFloatToInt converts a float64 to an int64 from the JSON field interface
@@ -19,3 +19,31 @@ func TestStructToMap(t *testing.T) {
 	_, foundAddressOK := result["address"]
 	assert.False(t, foundAddressOK)
 }
+
+func TestAPIErrorToError(t *testing.T) {
+	err := apiErrorToError(map[string]interface{}{
+		"errors": []interface{}{"Reference: must be unique - that value has been taken.", "Email: cannot be blank."},
+	})
+	validationErr, ok := err.(*ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, 2, len(validationErr.Errors))
+	assert.Equal(t, "Reference: must be unique - that value has been taken. Email: cannot be blank.", err.Error())
+	assert.True(t, validationErr.Contains("has been TAKEN"))
+	assert.False(t, validationErr.Contains("coupon"))
+
+	err = apiErrorToError(map[string]interface{}{
+		"errors": "Coupon code could not be found.",
+	})
+	assert.Equal(t, "Coupon code could not be found.", err.Error())
+
+	err = apiErrorToError(map[string]interface{}{
+		"errors": map[string]interface{}{
+			"quantity": []interface{}{"must be greater than 0"},
+		},
+	})
+	assert.Equal(t, "quantity: must be greater than 0", err.Error())
+
+	err = apiErrorToError("bad")
+	_, ok = err.(*ValidationError)
+	assert.False(t, ok)
+}
@@ -0,0 +1,317 @@
+package chargify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// SubscriptionGroupTargetType is the type of entity that a subscription is grouped with for invoice billing
+type SubscriptionGroupTargetType string
+
+var (
+	// SubscriptionGroupTargetParent groups the subscription with the customer's parent, who is billed for it
+	SubscriptionGroupTargetParent SubscriptionGroupTargetType = "parent"
+	// SubscriptionGroupTargetSelf groups the subscription with the customer's own subscriptions
+	SubscriptionGroupTargetSelf SubscriptionGroupTargetType = "self"
+	// SubscriptionGroupTargetCustomer groups the subscription with another customer, identified by the target ID
+	SubscriptionGroupTargetCustomer SubscriptionGroupTargetType = "customer"
+	// SubscriptionGroupTargetSubscription groups the subscription with another subscription, identified by the target ID
+	SubscriptionGroupTargetSubscription SubscriptionGroupTargetType = "subscription"
+	// SubscriptionGroupTargetEldest groups the subscription with the eldest customer in the hierarchy
+	SubscriptionGroupTargetEldest SubscriptionGroupTargetType = "eldest"
+)
+
+// SubscriptionGroupTarget identifies the entity a subscription is grouped with. The ID is only needed for the customer
+// and subscription target types.
+type SubscriptionGroupTarget struct {
+	Type SubscriptionGroupTargetType `json:"type"`
+	ID   int64                       `json:"id,omitempty"`
+}
+
+// SubscriptionGroupBilling controls how a subscription's charges are handled when it joins a group
+type SubscriptionGroupBilling struct {
+	Accrue    *bool `json:"accrue,omitempty"`     // (Optional, default false) Accrue the charges until the group's next billing date instead of charging immediately
+	AlignDate *bool `json:"align_date,omitempty"` // (Optional, default false) Align the subscription's billing date with the group's
+	Prorate   *bool `json:"prorate,omitempty"`    // (Optional, default false) Prorate the charges when the billing date is aligned
+}
+
+// SubscriptionGroupOptions are the options for placing a subscription in an invoice billing group when it is created
+type SubscriptionGroupOptions struct {
+	Target  SubscriptionGroupTarget   `json:"target"`
+	Billing *SubscriptionGroupBilling `json:"billing,omitempty"`
+}
+
+// SubscriptionGroupCustomer is the customer that pays for a subscription group
+type SubscriptionGroupCustomer struct {
+	FirstName    string `json:"first_name" mapstructure:"first_name"`
+	LastName     string `json:"last_name" mapstructure:"last_name"`
+	Organization string `json:"organization" mapstructure:"organization"`
+	Email        string `json:"email" mapstructure:"email"`
+	Reference    string `json:"reference" mapstructure:"reference"`
+}
+
+// SubscriptionGroupSubscription is a summary of a subscription in a group, returned when signing up a group
+type SubscriptionGroupSubscription struct {
+	ID                  int64             `json:"id" mapstructure:"id"`
+	State               SubscriptionState `json:"state" mapstructure:"state"`
+	BalanceInCents      int64             `json:"balance_in_cents" mapstructure:"balance_in_cents"`
+	ProductID           int64             `json:"product_id" mapstructure:"product_id"`
+	ProductPricePointID int64             `json:"product_price_point_id" mapstructure:"product_price_point_id"`
+	Reference           string            `json:"reference" mapstructure:"reference"`
+}
+
+// SubscriptionGroup is a group of subscriptions that share a payment profile and are billed together. Which fields are
+// filled in depends on the call that returned it.
+type SubscriptionGroup struct {
+	UID                         string                          `json:"uid" mapstructure:"uid"`
+	Scheme                      int64                           `json:"scheme" mapstructure:"scheme"`
+	CustomerID                  int64                           `json:"customer_id" mapstructure:"customer_id"`
+	Customer                    *SubscriptionGroupCustomer      `json:"customer" mapstructure:"customer"`
+	PaymentProfileID            int64                           `json:"payment_profile_id" mapstructure:"payment_profile_id"` // The payment profile shared by the group
+	PaymentCollectionMethod     string                          `json:"payment_collection_method" mapstructure:"payment_collection_method"`
+	SubscriptionIDs             []int64                         `json:"subscription_ids" mapstructure:"subscription_ids"`               // Every subscription in the group
+	PrimarySubscriptionID       int64                           `json:"primary_subscription_id" mapstructure:"primary_subscription_id"` // The subscription the group's billing is based on
+	NextAssessmentAt            string                          `json:"next_assessment_at" mapstructure:"next_assessment_at"`
+	State                       string                          `json:"state" mapstructure:"state"`
+	CancelAtEndOfPeriod         bool                            `json:"cancel_at_end_of_period" mapstructure:"cancel_at_end_of_period"`
+	CurrentBillingAmountInCents int64                           `json:"current_billing_amount_in_cents" mapstructure:"current_billing_amount_in_cents"` // The amount the group will be billed next, only from GetSubscriptionGroup
+	AccountBalances             *AccountBalances                `json:"account_balances" mapstructure:"account_balances"`                               // The shared balances, only from FindSubscriptionGroup
+	Subscriptions               []SubscriptionGroupSubscription `json:"subscriptions" mapstructure:"subscriptions"`                                     // The subscriptions created, only from SignupSubscriptionGroup
+	CreatedAt                   string                          `json:"created_at" mapstructure:"created_at"`
+}
+
+// SubscriptionGroupSignupItem is a single subscription to create when signing up a group. The product must be specified
+// with one of ProductID or ProductHandle, and exactly one item in the signup must be the primary.
+type SubscriptionGroupSignupItem struct {
+	ProductID           *int64                       `json:"product_id,omitempty"`
+	ProductHandle       string                       `json:"product_handle,omitempty"`
+	ProductPricePointID *int64                       `json:"product_price_point_id,omitempty"`
+	Primary             bool                         `json:"primary,omitempty"` // Whether the group's billing is based on this subscription
+	Currency            string                       `json:"currency,omitempty"`
+	CouponCodes         []string                     `json:"coupon_codes,omitempty"`
+	Components          []SubscriptionComponentInput `json:"components,omitempty"`
+	CustomPrice         *SubscriptionCustomPrice     `json:"custom_price,omitempty"`
+	Reference           string                       `json:"reference,omitempty"`
+	Metafields          map[string]string            `json:"metafields,omitempty"`
+}
+
+// SubscriptionGroupSignupInput creates a group of subscriptions for a single payer in one call. The payer must be specified
+// with one of PayerID, PayerReference, or PayerAttributes, and the payment method with one of PaymentProfileID or
+// PaymentProfileAttributes.
+type SubscriptionGroupSignupInput struct {
+	PayerID                  *int64                        `json:"payer_id,omitempty"`
+	PayerReference           string                        `json:"payer_reference,omitempty"`
+	PayerAttributes          *CustomerUpdate               `json:"payer_attributes,omitempty"`
+	PaymentProfileID         *int64                        `json:"payment_profile_id,omitempty"`
+	PaymentProfileAttributes *PaymentProfileAttributes     `json:"payment_profile_attributes,omitempty"`
+	PaymentCollectionMethod  string                        `json:"payment_collection_method,omitempty"`
+	Subscriptions            []SubscriptionGroupSignupItem `json:"subscriptions"`
+}
+
+func (input *SubscriptionGroupSignupInput) validate() error {
+	payers := 0
+	if input.PayerID != nil {
+		payers++
+	}
+	if input.PayerReference != "" {
+		payers++
+	}
+	if input.PayerAttributes != nil {
+		payers++
+	}
+	if payers != 1 {
+		return errors.New("exactly one of payer id, payer reference, or payer attributes is required")
+	}
+	if (input.PaymentProfileID == nil) == (input.PaymentProfileAttributes == nil) {
+		return errors.New("exactly one of payment profile id or payment profile attributes is required")
+	}
+	if len(input.Subscriptions) == 0 {
+		return errors.New("at least one subscription is required")
+	}
+	primaries := 0
+	for i := range input.Subscriptions {
+		if (input.Subscriptions[i].ProductID == nil) == (input.Subscriptions[i].ProductHandle == "") {
+			return errors.New("exactly one of product id or product handle is required for every subscription")
+		}
+		if input.Subscriptions[i].Primary {
+			primaries++
+		}
+	}
+	if primaries != 1 {
+		return errors.New("exactly one subscription must be the primary")
+	}
+	return nil
+}
+
+// CreateSubscriptionGroup creates a group from existing subscriptions. The primary subscription's payment profile is used
+// for the whole group. All of the subscriptions must belong to the same customer or its hierarchy.
+func CreateSubscriptionGroup(primarySubscriptionID int64, memberIDs []int64) (*SubscriptionGroup, error) {
+	if memberIDs == nil {
+		memberIDs = []int64{}
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionGroupCreate],
+		Body: map[string]map[string]interface{}{
+			"subscription_group": {
+				"subscription_id": primarySubscriptionID,
+				"member_ids":      memberIDs,
+			},
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionGroupFromResponse(ret)
+}
+
+// GetSubscriptionGroup gets a subscription group by its uid, including the amount the group will be billed next
+func GetSubscriptionGroup(uid string) (*SubscriptionGroup, error) {
+	if uid == "" {
+		return nil, errors.New("uid is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionGroupGet],
+		PathParams: &map[string]string{
+			"uid": uid,
+		},
+		MultiQueryParams: &map[string][]string{
+			"include[]": {"current_billing_amount_in_cents"},
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionGroupFromResponse(ret)
+}
+
+// FindSubscriptionGroup finds the group a subscription belongs to, including the group's shared account balances. If the
+// subscription is not in a group, ErrNotFound is returned.
+func FindSubscriptionGroup(subscriptionID int64) (*SubscriptionGroup, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionGroupLookup],
+		QueryParams: &map[string]string{
+			"subscription_id": fmt.Sprintf("%d", subscriptionID),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionGroupFromResponse(ret)
+}
+
+// UpdateSubscriptionGroupMembers sets the members of a group, other than the primary subscription. Subscriptions that are
+// left out are removed from the group.
+func UpdateSubscriptionGroupMembers(uid string, memberIDs []int64) (*SubscriptionGroup, error) {
+	if uid == "" {
+		return nil, errors.New("uid is required")
+	}
+	if memberIDs == nil {
+		memberIDs = []int64{}
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionGroupUpdate],
+		PathParams: &map[string]string{
+			"uid": uid,
+		},
+		Body: map[string]map[string][]int64{
+			"subscription_group": {
+				"member_ids": memberIDs,
+			},
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionGroupFromResponse(ret)
+}
+
+// DeleteSubscriptionGroup deletes a group. Only groups without any subscriptions besides the primary can be deleted.
+func DeleteSubscriptionGroup(uid string) error {
+	if uid == "" {
+		return errors.New("uid is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionGroupDelete],
+		PathParams: &map[string]string{
+			"uid": uid,
+		},
+	}
+	_, err := makeAPICall(options)
+	return err
+}
+
+// AddSubscriptionToGroup adds an existing subscription to a group, identified by the group target, such as another
+// subscription or the customer's parent
+func AddSubscriptionToGroup(subscriptionID int64, group *SubscriptionGroupOptions) (*SubscriptionGroup, error) {
+	if group == nil || group.Target.Type == "" {
+		return nil, errors.New("group target type is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionGroupAddMember],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: map[string]SubscriptionGroupOptions{
+			"group": *group,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionGroupFromResponse(ret)
+}
+
+// RemoveSubscriptionFromGroup removes a subscription from its group. The subscription keeps its own billing from then on.
+func RemoveSubscriptionFromGroup(subscriptionID int64) error {
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionGroupRemoveMember],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+	}
+	_, err := makeAPICall(options)
+	return err
+}
+
+// SignupSubscriptionGroup creates a payer, payment profile, and group of subscriptions in one call
+func SignupSubscriptionGroup(input *SubscriptionGroupSignupInput) (*SubscriptionGroup, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionGroupSignup],
+		Body: map[string]SubscriptionGroupSignupInput{
+			"subscription_group": *input,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionGroupFromResponse(ret)
+}
+
+// subscriptionGroupFromResponse decodes a group, which some calls wrap in a subscription_group key and others do not
+func subscriptionGroupFromResponse(ret APIReturn) (*SubscriptionGroup, error) {
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	var raw interface{} = apiBody
+	if wrapped, wrappedOK := apiBody["subscription_group"]; wrappedOK {
+		raw = wrapped
+	}
+	group := &SubscriptionGroup{}
+	err := mapstructure.Decode(raw, group)
+	return group, err
+}
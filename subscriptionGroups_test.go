@@ -0,0 +1,62 @@
+package chargify
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionGroupSignupValidation(t *testing.T) {
+	input := &SubscriptionGroupSignupInput{}
+	assert.NotNil(t, input.validate())
+	input.PayerReference = "payer"
+	assert.NotNil(t, input.validate())
+	input.PaymentProfileID = FromInt64(1)
+	assert.NotNil(t, input.validate())
+	input.Subscriptions = []SubscriptionGroupSignupItem{
+		{ProductHandle: "gold"},
+		{ProductHandle: "silver"},
+	}
+	assert.NotNil(t, input.validate())
+	input.Subscriptions[0].Primary = true
+	assert.Nil(t, input.validate())
+	input.Subscriptions[1].Primary = true
+	assert.NotNil(t, input.validate())
+	input.Subscriptions[1].Primary = false
+	input.Subscriptions[1].ProductID = FromInt64(2)
+	assert.NotNil(t, input.validate())
+	input.Subscriptions[1].ProductID = nil
+	input.PayerID = FromInt64(3)
+	assert.NotNil(t, input.validate())
+}
+
+func TestSubscriptionGroupFromResponse(t *testing.T) {
+	wrapped := APIReturn{}
+	err := json.Unmarshal([]byte(`{"body": {"subscription_group": {
+		"uid": "grp_1", "customer_id": 5, "subscription_ids": [1, 2], "primary_subscription_id": 1,
+		"subscriptions": [{"id": 1, "state": "active"}, {"id": 2, "state": "active"}]
+	}}}`), &wrapped)
+	require.Nil(t, err)
+	group, err := subscriptionGroupFromResponse(wrapped)
+	require.Nil(t, err)
+	assert.Equal(t, "grp_1", group.UID)
+	assert.Equal(t, []int64{1, 2}, group.SubscriptionIDs)
+	require.Equal(t, 2, len(group.Subscriptions))
+	assert.Equal(t, SubscriptionStateActive, group.Subscriptions[1].State)
+
+	// the lookup is not wrapped and includes the balances
+	unwrapped := APIReturn{}
+	err = json.Unmarshal([]byte(`{"body": {
+		"uid": "grp_2", "primary_subscription_id": 7,
+		"account_balances": {"prepayments": {"balance_in_cents": 100}, "service_credits": {"balance_in_cents": 50}, "open_invoices": {"balance_in_cents": 0}}
+	}}`), &unwrapped)
+	require.Nil(t, err)
+	group, err = subscriptionGroupFromResponse(unwrapped)
+	require.Nil(t, err)
+	assert.Equal(t, int64(7), group.PrimarySubscriptionID)
+	require.NotNil(t, group.AccountBalances)
+	assert.Equal(t, int64(100), group.AccountBalances.Prepayments.BalanceInCents)
+	assert.Equal(t, int64(50), group.AccountBalances.ServiceCredits.BalanceInCents)
+}
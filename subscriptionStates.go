@@ -0,0 +1,169 @@
+package chargify
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SubscriptionState is the state of a subscription in Chargify
+type SubscriptionState string
+
+var (
+	// SubscriptionStateTrialing is a subscription in a trial period
+	SubscriptionStateTrialing SubscriptionState = "trialing"
+	// SubscriptionStateActive is a subscription in good standing
+	SubscriptionStateActive SubscriptionState = "active"
+	// SubscriptionStateAssessing is a subscription that is being renewed right now
+	SubscriptionStateAssessing SubscriptionState = "assessing"
+	// SubscriptionStatePending is a subscription that is being created right now
+	SubscriptionStatePending SubscriptionState = "pending"
+	// SubscriptionStatePastDue is a subscription with a failed renewal that is being retried through dunning
+	SubscriptionStatePastDue SubscriptionState = "past_due"
+	// SubscriptionStateSoftFailure is a subscription that failed renewal and is being retried without a full dunning process
+	SubscriptionStateSoftFailure SubscriptionState = "soft_failure"
+	// SubscriptionStateUnpaid is a subscription that failed dunning and was left unpaid instead of canceled
+	SubscriptionStateUnpaid SubscriptionState = "unpaid"
+	// SubscriptionStateOnHold is a subscription that has been paused
+	SubscriptionStateOnHold SubscriptionState = "on_hold"
+	// SubscriptionStateAwaitingSignup is a subscription created with a delayed signup that has not started
+	SubscriptionStateAwaitingSignup SubscriptionState = "awaiting_signup"
+	// SubscriptionStateCanceled is a subscription that was canceled, either by request or after failing dunning
+	SubscriptionStateCanceled SubscriptionState = "canceled"
+	// SubscriptionStateExpired is a subscription that reached its expiration date
+	SubscriptionStateExpired SubscriptionState = "expired"
+	// SubscriptionStateTrialEnded is a subscription whose trial ended without a way to pay for the product
+	SubscriptionStateTrialEnded SubscriptionState = "trial_ended"
+	// SubscriptionStateSuspended is a subscription that was suspended by Chargify
+	SubscriptionStateSuspended SubscriptionState = "suspended"
+	// SubscriptionStateFailedToCreate is a subscription whose signup failed
+	SubscriptionStateFailedToCreate SubscriptionState = "failed_to_create"
+)
+
+// SubscriptionOperation is an operation that changes the state of a subscription
+type SubscriptionOperation string
+
+var (
+	// SubscriptionOperationCancel cancels a subscription immediately
+	SubscriptionOperationCancel SubscriptionOperation = "cancel"
+	// SubscriptionOperationDelayedCancel cancels a subscription at the end of the current period
+	SubscriptionOperationDelayedCancel SubscriptionOperation = "delayed_cancel"
+	// SubscriptionOperationReactivate reactivates a canceled, expired, or trial ended subscription
+	SubscriptionOperationReactivate SubscriptionOperation = "reactivate"
+	// SubscriptionOperationHold pauses a subscription
+	SubscriptionOperationHold SubscriptionOperation = "hold"
+	// SubscriptionOperationResume resumes a paused subscription
+	SubscriptionOperationResume SubscriptionOperation = "resume"
+	// SubscriptionOperationChangeProduct changes the product of a subscription, now or at renewal
+	SubscriptionOperationChangeProduct SubscriptionOperation = "change_product"
+	// SubscriptionOperationRetry retries the renewal payment of a delinquent subscription
+	SubscriptionOperationRetry SubscriptionOperation = "retry"
+)
+
+// subscriptionTransitions is the table of the states each operation may be performed from. It mirrors the rules
+// Chargify documents for each endpoint:
+//
+//	cancel          trialing, active, assessing, pending, past_due, soft_failure, unpaid, on_hold, awaiting_signup
+//	delayed_cancel  trialing, active, past_due, soft_failure
+//	reactivate      canceled, expired, trial_ended
+//	hold            trialing, active
+//	resume          on_hold
+//	change_product  trialing, active, past_due, soft_failure
+//	retry           past_due, soft_failure, unpaid
+var subscriptionTransitions = map[SubscriptionOperation][]SubscriptionState{
+	SubscriptionOperationCancel: {
+		SubscriptionStateTrialing, SubscriptionStateActive, SubscriptionStateAssessing, SubscriptionStatePending,
+		SubscriptionStatePastDue, SubscriptionStateSoftFailure, SubscriptionStateUnpaid, SubscriptionStateOnHold,
+		SubscriptionStateAwaitingSignup,
+	},
+	SubscriptionOperationDelayedCancel: {
+		SubscriptionStateTrialing, SubscriptionStateActive, SubscriptionStatePastDue, SubscriptionStateSoftFailure,
+	},
+	SubscriptionOperationReactivate: {
+		SubscriptionStateCanceled, SubscriptionStateExpired, SubscriptionStateTrialEnded,
+	},
+	SubscriptionOperationHold: {
+		SubscriptionStateTrialing, SubscriptionStateActive,
+	},
+	SubscriptionOperationResume: {
+		SubscriptionStateOnHold,
+	},
+	SubscriptionOperationChangeProduct: {
+		SubscriptionStateTrialing, SubscriptionStateActive, SubscriptionStatePastDue, SubscriptionStateSoftFailure,
+	},
+	SubscriptionOperationRetry: {
+		SubscriptionStatePastDue, SubscriptionStateSoftFailure, SubscriptionStateUnpaid,
+	},
+}
+
+// StateTransitionError is returned when an operation is not allowed from the current state of a subscription
+type StateTransitionError struct {
+	Operation SubscriptionOperation
+	State     SubscriptionState
+	Allowed   []SubscriptionState
+}
+
+func (e *StateTransitionError) Error() string {
+	allowed := make([]string, len(e.Allowed))
+	for i := range e.Allowed {
+		allowed[i] = string(e.Allowed[i])
+	}
+	return fmt.Sprintf("cannot %s a subscription that is %s; allowed states are %s", strings.ReplaceAll(string(e.Operation), "_", " "), e.State, strings.Join(allowed, ", "))
+}
+
+// IsLive returns true if the subscription should still be receiving service, including while a failed renewal is being retried
+func (state SubscriptionState) IsLive() bool {
+	switch state {
+	case SubscriptionStateTrialing, SubscriptionStateActive, SubscriptionStateAssessing, SubscriptionStatePending,
+		SubscriptionStatePastDue, SubscriptionStateSoftFailure:
+		return true
+	}
+	return false
+}
+
+// IsDelinquent returns true if the subscription has a renewal payment outstanding
+func (state SubscriptionState) IsDelinquent() bool {
+	switch state {
+	case SubscriptionStatePastDue, SubscriptionStateSoftFailure, SubscriptionStateUnpaid:
+		return true
+	}
+	return false
+}
+
+// IsEnded returns true if the subscription has ended and will not renew without being reactivated
+func (state SubscriptionState) IsEnded() bool {
+	switch state {
+	case SubscriptionStateCanceled, SubscriptionStateExpired, SubscriptionStateTrialEnded, SubscriptionStateSuspended,
+		SubscriptionStateFailedToCreate:
+		return true
+	}
+	return false
+}
+
+// CanPerform checks the operation against the transition table and returns a *StateTransitionError if it is not allowed
+// from the state. States and operations the table does not know about are allowed, leaving the decision to Chargify.
+func (state SubscriptionState) CanPerform(operation SubscriptionOperation) error {
+	allowed, found := subscriptionTransitions[operation]
+	if !found || !state.isKnown() {
+		return nil
+	}
+	for i := range allowed {
+		if allowed[i] == state {
+			return nil
+		}
+	}
+	return &StateTransitionError{
+		Operation: operation,
+		State:     state,
+		Allowed:   allowed,
+	}
+}
+
+func (state SubscriptionState) isKnown() bool {
+	return state.IsLive() || state.IsDelinquent() || state.IsEnded() ||
+		state == SubscriptionStateOnHold || state == SubscriptionStateAwaitingSignup
+}
+
+// CanPerform checks whether the operation is allowed from the current state of the subscription; see SubscriptionState.CanPerform
+func (subscription *Subscription) CanPerform(operation SubscriptionOperation) error {
+	return subscription.State.CanPerform(operation)
+}
@@ -0,0 +1,48 @@
+package chargify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionStatePredicates(t *testing.T) {
+	assert.True(t, SubscriptionStateActive.IsLive())
+	assert.True(t, SubscriptionStatePastDue.IsLive())
+	assert.False(t, SubscriptionStateOnHold.IsLive())
+	assert.False(t, SubscriptionStateCanceled.IsLive())
+
+	assert.True(t, SubscriptionStateSoftFailure.IsDelinquent())
+	assert.True(t, SubscriptionStateUnpaid.IsDelinquent())
+	assert.False(t, SubscriptionStateActive.IsDelinquent())
+
+	assert.True(t, SubscriptionStateExpired.IsEnded())
+	assert.True(t, SubscriptionStateTrialEnded.IsEnded())
+	assert.False(t, SubscriptionStateUnpaid.IsEnded())
+}
+
+func TestSubscriptionStateTransitions(t *testing.T) {
+	assert.Nil(t, SubscriptionStateActive.CanPerform(SubscriptionOperationHold))
+	assert.Nil(t, SubscriptionStateOnHold.CanPerform(SubscriptionOperationResume))
+	assert.Nil(t, SubscriptionStateCanceled.CanPerform(SubscriptionOperationReactivate))
+	assert.Nil(t, SubscriptionStatePastDue.CanPerform(SubscriptionOperationRetry))
+
+	err := SubscriptionStateCanceled.CanPerform(SubscriptionOperationHold)
+	require.NotNil(t, err)
+	transitionErr := &StateTransitionError{}
+	require.True(t, errors.As(err, &transitionErr))
+	assert.Equal(t, SubscriptionOperationHold, transitionErr.Operation)
+	assert.Equal(t, SubscriptionStateCanceled, transitionErr.State)
+	assert.Equal(t, "cannot hold a subscription that is canceled; allowed states are trialing, active", err.Error())
+
+	subscription := &Subscription{State: SubscriptionStateActive}
+	assert.NotNil(t, subscription.CanPerform(SubscriptionOperationReactivate))
+	assert.NotNil(t, subscription.CanPerform(SubscriptionOperationResume))
+	assert.Nil(t, subscription.CanPerform(SubscriptionOperationDelayedCancel))
+
+	// unknown states and operations are left to Chargify
+	assert.Nil(t, SubscriptionState("something_new").CanPerform(SubscriptionOperationHold))
+	assert.Nil(t, SubscriptionStateActive.CanPerform(SubscriptionOperation("something_new")))
+}
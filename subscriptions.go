@@ -32,8 +32,20 @@ type Subscription struct {
 	ReceivesInvoiceEmails         bool      `json:"receives_invoice_emails" mapstructure:"receives_invoice_emails"`                             // (Optional) Default: True - Whether or not this subscription is set to receive emails related to this subscription.
 	Customer                      *Customer `json:"customer,omitempty" mapstructure:"customer"`
 	Product                       *Product  `json:"product,omitempty" mapstructure:"product"`
+	// only used on creation to place the subscription in an invoice billing group, such as one billed to the customer's parent
+	Group *SubscriptionGroupOptions `json:"group,omitempty" mapstructure:"-"`
 	// some of these are only used on the return
-	State string `json:"state,omitempty" mapstructure:"state"` // the state of the subscription
+	State            SubscriptionState `json:"state,omitempty" mapstructure:"state"`                           // the state of the subscription
+	NextProductID    int64             `json:"next_product_id,omitempty" mapstructure:"next_product_id"`       // the product the subscription will change to at renewal, if a delayed product change is pending
+	CouponCodes      []string          `json:"coupon_codes,omitempty" mapstructure:"coupon_codes"`             // the codes of the coupons currently applied
+	NextAssessmentAt string            `json:"next_assessment_at,omitempty" mapstructure:"next_assessment_at"` // when the subscription will next be charged, including dunning retries
+	// only read; change the price point with ChangeSubscriptionProduct or MigrateSubscriptionWithOptions
+	ProductPricePointID   int64  `json:"product_price_point_id,omitempty" mapstructure:"product_price_point_id"`     // the product price point the subscription is on
+	ProductPricePointType string `json:"product_price_point_type,omitempty" mapstructure:"product_price_point_type"` // the type of the price point, such as default or custom
+	// these are only read, except through OverrideSubscription
+	ActivatedAt            string `json:"activated_at,omitempty" mapstructure:"activated_at"`                           // when the subscription was activated
+	CanceledAt             string `json:"canceled_at,omitempty" mapstructure:"canceled_at"`                             // when the subscription was canceled
+	CurrentPeriodStartedAt string `json:"current_period_started_at,omitempty" mapstructure:"current_period_started_at"` // when the current billing period started
 }
 
 type SubscriptionComponent struct {
@@ -47,7 +59,7 @@ type SubscriptionComponent struct {
 	PricePointID      int64  `json:"price_point_id" mapstructure:"price_point_id"`
 	PricePointHandle  string `json:"price_point_handle" mapstructure:"price_point_handle"`
 	PricePointType    string `json:"price_point_type" mapstructure:"price_point_type"`
-	PricePointName    string `json:"price_point_type" mapstructure:"price_point_type"`
+	PricePointName    string `json:"price_point_name" mapstructure:"price_point_name"`
 	Enabled           bool   `json:"enabled" mapstructure:"enabled"`
 	UnitBalance       int64  `json:"unit_balance" mapstructure:"unit_balance"`
 	ID                int64  `json:"id" mapstructure:"id"`
@@ -128,69 +140,296 @@ func (input *ListSubscriptionsQueryParams) toMap() *map[string]string {
 	return &m
 }
 
+// CreateSubscriptionInput holds the options for creating a subscription. The customer must be specified with one of
+// CustomerID, CustomerReference, or CustomerAttributes, and the product with one of ProductID or ProductHandle, optionally
+// along with a price point. Only the fields that are set are sent to Chargify.
+type CreateSubscriptionInput struct {
+	ProductID                         *int64                       `json:"product_id,omitempty"`                            // The ID of the product to subscribe to
+	ProductHandle                     string                       `json:"product_handle,omitempty"`                        // The handle of the product to subscribe to
+	ProductPricePointID               *int64                       `json:"product_price_point_id,omitempty"`                // (Optional) The ID of the product price point to use instead of the default
+	ProductPricePointHandle           string                       `json:"product_price_point_handle,omitempty"`            // (Optional) The handle of the product price point to use instead of the default
+	CustomPrice                       *SubscriptionCustomPrice     `json:"custom_price,omitempty"`                          // (Optional) A price specific to this subscription, instead of a price point
+	CustomerID                        *int64                       `json:"customer_id,omitempty"`                           // The ID of an existing customer
+	CustomerReference                 string                       `json:"customer_reference,omitempty"`                    // The reference of an existing customer
+	CustomerAttributes                *CustomerUpdate              `json:"customer_attributes,omitempty"`                   // The attributes of a new customer to create along with the subscription
+	PaymentProfileID                  *int64                       `json:"payment_profile_id,omitempty"`                    // (Optional) The ID of an existing payment profile for the customer
+	PaymentProfileAttributes          *PaymentProfileAttributes    `json:"payment_profile_attributes,omitempty"`            // (Optional) The attributes of a new payment profile, including a Chargify.js token
+	CouponCodes                       []string                     `json:"coupon_codes,omitempty"`                          // (Optional) The codes of the coupons to apply
+	Components                        []SubscriptionComponentInput `json:"components,omitempty"`                            // (Optional) The initial component quantities
+	Metafields                        map[string]string            `json:"metafields,omitempty"`                            // (Optional) Metadata to set on the subscription, keyed by metafield name
+	Reference                         string                       `json:"reference,omitempty"`                             // (Optional) The unique identifier used within your own application for this subscription
+	NextBillingAt                     string                       `json:"next_billing_at,omitempty"`                       // (Optional) Sync imported subscriptions to an existing renewal schedule; no charges are made at signup
+	InitialBillingAt                  string                       `json:"initial_billing_at,omitempty"`                    // (Optional) Delay the signup charges until this date
+	ExpiresAt                         string                       `json:"expires_at,omitempty"`                            // (Optional) The date the subscription expires
+	ExpirationTracksNextBillingChange *bool                        `json:"expiration_tracks_next_billing_change,omitempty"` // (Optional) Shift expires_at along with changes to next_billing_at
+	VATNumber                         string                       `json:"vat_number,omitempty"`                            // (Optional) The VAT number, without the country code
+	PaymentCollectionMethod           string                       `json:"payment_collection_method,omitempty"`             // (Optional) Either automatic or remittance
+	CalendarBilling                   *SubscriptionCalendarBilling `json:"calendar_billing,omitempty"`                      // (Optional) Align billing to a day of the month. Cannot be used with next_billing_at
+	ReceivesInvoiceEmails             *bool                        `json:"receives_invoice_emails,omitempty"`               // (Optional, default true) Whether the subscription receives invoice emails
+	Currency                          string                       `json:"currency,omitempty"`                              // (Optional) The currency of the subscription, if not the site default
+	AgreementTerms                    string                       `json:"agreement_terms,omitempty"`                       // (Optional) The ACH authorization agreement terms
+	AuthorizerFirstName               string                       `json:"authorizer_first_name,omitempty"`                 // (Optional) The first name of the person authorizing the ACH agreement
+	AuthorizerLastName                string                       `json:"authorizer_last_name,omitempty"`                  // (Optional) The last name of the person authorizing the ACH agreement
+	Ref                               string                       `json:"ref,omitempty"`                                   // (Optional) A referral code
+	Group                             *SubscriptionGroupOptions    `json:"group,omitempty"`                                 // (Optional) Place the subscription in an invoice billing group
+}
+
+// SubscriptionCustomPrice is a price that only applies to a single subscription
+type SubscriptionCustomPrice struct {
+	PriceInCents            int64           `json:"price_in_cents"`                       // The price, in integer cents
+	IntervalUnit            ProductInterval `json:"interval_unit"`                        // The interval unit, either month or day
+	Interval                int             `json:"interval"`                             // The numerical interval
+	TrialPriceInCents       *int64          `json:"trial_price_in_cents,omitempty"`       // (Optional) The trial price, in integer cents
+	TrialInterval           *int            `json:"trial_interval,omitempty"`             // (Optional) The numerical trial interval
+	TrialIntervalUnit       ProductInterval `json:"trial_interval_unit,omitempty"`        // (Optional) The trial interval unit
+	InitialChargeInCents    *int64          `json:"initial_charge_in_cents,omitempty"`    // (Optional) The up front charge, in integer cents
+	InitialChargeAfterTrial *bool           `json:"initial_charge_after_trial,omitempty"` // (Optional) Charge the initial charge after the trial instead of at signup
+	ExpirationInterval      *int            `json:"expiration_interval,omitempty"`        // (Optional) The numerical interval before the subscription expires
+	ExpirationIntervalUnit  ProductInterval `json:"expiration_interval_unit,omitempty"`   // (Optional) The expiration interval unit
+	TaxIncluded             *bool           `json:"tax_included,omitempty"`               // (Optional) Whether the price includes tax
+}
+
+// SubscriptionCalendarBilling aligns the billing of a subscription to a specific day of the month
+type SubscriptionCalendarBilling struct {
+	SnapDay                    string `json:"snap_day,omitempty"`                      // A value between 1 and 28, or end
+	CalendarBillingFirstCharge string `json:"calendar_billing_first_charge,omitempty"` // (Optional) One of prorated (the default), immediate, or delayed
+}
+
+// SubscriptionComponentInput sets the initial quantity of a component when creating a subscription
+type SubscriptionComponentInput struct {
+	ComponentID       int64  `json:"component_id"`                 // The ID of the component
+	AllocatedQuantity *int64 `json:"allocated_quantity,omitempty"` // The quantity for quantity-based components
+	Enabled           *bool  `json:"enabled,omitempty"`            // Whether an on/off component is enabled
+	UnitBalance       *int64 `json:"unit_balance,omitempty"`       // The starting balance for metered components
+	PricePointID      *int64 `json:"price_point_id,omitempty"`     // (Optional) The component price point to use instead of the default
+}
+
+// PaymentProfileAttributes are the attributes of a new payment profile to create along with a subscription. Either the
+// card or bank details, a vault token, or a ChargifyToken from Chargify.js must be provided.
+type PaymentProfileAttributes struct {
+	ChargifyToken         string      `json:"chargify_token,omitempty"`           // The token received from Chargify.js
+	PaymentType           string      `json:"payment_type,omitempty"`             // Default is credit_card. May be bank_account or credit_card or paypal_account.
+	FirstName             string      `json:"first_name,omitempty"`               // First name on card or bank account
+	LastName              string      `json:"last_name,omitempty"`                // Last name on card or bank account
+	FullNumber            string      `json:"full_number,omitempty"`              // The full credit card number
+	CardType              string      `json:"card_type,omitempty"`                // The type of card, such as visa or master
+	ExpirationMonth       string      `json:"expiration_month,omitempty"`         // The 1- or 2-digit credit card expiration month
+	ExpirationYear        string      `json:"expiration_year,omitempty"`          // The 4-digit credit card expiration year
+	CVV                   string      `json:"cvv,omitempty"`                      // The 3- or 4-digit Card Verification Value
+	VaultToken            string      `json:"vault_token,omitempty"`              // An existing token from your gateway
+	CustomerVaultToken    string      `json:"customer_vault_token,omitempty"`     // An existing customer token from your gateway
+	CurrentVault          VaultMethod `json:"current_vault,omitempty"`            // The vault that stores the vault token
+	BillingAddress        string      `json:"billing_address,omitempty"`          // The billing street address
+	BillingAddress2       string      `json:"billing_address_2,omitempty"`        // Second line of the billing address
+	BillingCity           string      `json:"billing_city,omitempty"`             // The billing address city
+	BillingState          string      `json:"billing_state,omitempty"`            // The billing address state
+	BillingZip            string      `json:"billing_zip,omitempty"`              // The billing address zip code
+	BillingCountry        string      `json:"billing_country,omitempty"`          // The billing address country
+	BankName              string      `json:"bank_name,omitempty"`                // The name of the bank where the customer’s account resides
+	BankRouting           string      `json:"bank_routing_number,omitempty"`      // The routing number of the bank
+	BankAccount           string      `json:"bank_account_number,omitempty"`      // The customer’s bank account number
+	BankAccountType       string      `json:"bank_account_type,omitempty"`        // Either checking or savings
+	BankAccountHolderType string      `json:"bank_account_holder_type,omitempty"` // Either personal or business
+	PaypalEmail           string      `json:"paypal_email,omitempty"`             // The PayPal email address
+	PaymentMethodNonce    string      `json:"payment_method_nonce,omitempty"`     // The Braintree payment method nonce
+}
+
+// validate checks the input for conflicting options. The customer is only optional when previewing a signup.
+func (input *CreateSubscriptionInput) validate(customerRequired bool) error {
+	customers := 0
+	if input.CustomerID != nil {
+		customers++
+	}
+	if input.CustomerReference != "" {
+		customers++
+	}
+	if input.CustomerAttributes != nil {
+		customers++
+	}
+	if customers > 1 || (customerRequired && customers == 0) {
+		return errors.New("exactly one of customer id, customer reference, or customer attributes is required")
+	}
+	if (input.ProductID == nil) == (input.ProductHandle == "") {
+		return errors.New("exactly one of product id or product handle is required")
+	}
+	if input.ProductPricePointID != nil && input.ProductPricePointHandle != "" {
+		return errors.New("only one of product price point id or product price point handle may be provided")
+	}
+	if input.CustomPrice != nil && (input.ProductPricePointID != nil || input.ProductPricePointHandle != "") {
+		return errors.New("a custom price cannot be used with a product price point")
+	}
+	if input.PaymentProfileID != nil && input.PaymentProfileAttributes != nil {
+		return errors.New("only one of payment profile id or payment profile attributes may be provided")
+	}
+	if input.NextBillingAt != "" && input.CalendarBilling != nil {
+		return errors.New("calendar billing cannot be used with next billing at")
+	}
+	return nil
+}
+
+// CreateSubscription creates a new subscription with all of the options on the input
+func CreateSubscription(input *CreateSubscriptionInput) (*Subscription, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if err := input.validate(true); err != nil {
+		return nil, err
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionCreate],
+		Body: map[string]CreateSubscriptionInput{
+			"subscription": *input,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionFromResponse(ret)
+}
+
 // CreateSubscriptionForCustomer creates a new subscription. When creating a subscription, you must specify a product and a customer.
 // The product should be specificed by productHandle and the customer should be specified with customerReference. The subscriptionOptions
-// pointer is useful for specifying select additional options. Right now, only NextChargeAt is supported.
+// pointer is useful for specifying additional options; see newCreateSubscriptionInput for the fields that are honored. Use
+// CreateSubscription for access to every option.
 // The paymentProfileID is optional and is used to associate the subscription with a payment profile. If one is already setup,
 // pass in 0.
 func CreateSubscriptionForCustomer(customerReference, productHandle string, paymentProfileID int64, subscriptionOptions *Subscription) (*Subscription, error) {
-	body := map[string]map[string]interface{}{
-		"subscription": {
-			"customer_reference": customerReference,
-			"product_handle":     productHandle,
-		},
+	return CreateSubscription(newCreateSubscriptionInput(customerReference, productHandle, paymentProfileID, subscriptionOptions))
+}
+
+// newCreateSubscriptionInput converts the arguments of CreateSubscriptionForCustomer into a CreateSubscriptionInput. Since the
+// Subscription fields are not pointers, zero values are treated as not set; this means ReceivesInvoiceEmails and
+// ExpirationTracksChange are only sent when true.
+func newCreateSubscriptionInput(customerReference, productHandle string, paymentProfileID int64, subscriptionOptions *Subscription) *CreateSubscriptionInput {
+	input := &CreateSubscriptionInput{
+		CustomerReference: customerReference,
+		ProductHandle:     productHandle,
 	}
 	if paymentProfileID != 0 {
-		body["subscription"]["payment_profile_id"] = paymentProfileID
+		input.PaymentProfileID = &paymentProfileID
 	}
-	if subscriptionOptions != nil {
-		if subscriptionOptions.NextBillingAt != "" {
-			body["subscription"]["next_billing_at"] = subscriptionOptions.NextBillingAt
+	if subscriptionOptions == nil {
+		return input
+	}
+	if subscriptionOptions.CouponCode != "" {
+		input.CouponCodes = []string{subscriptionOptions.CouponCode}
+	}
+	input.NextBillingAt = subscriptionOptions.NextBillingAt
+	input.ExpiresAt = subscriptionOptions.ExpiresAt
+	input.VATNumber = subscriptionOptions.VATNumber
+	input.PaymentCollectionMethod = subscriptionOptions.PaymentCollectionMethod
+	input.AgreementTerms = subscriptionOptions.AgreementTerms
+	input.AuthorizerFirstName = subscriptionOptions.ACHFirstName
+	input.AuthorizerLastName = subscriptionOptions.ACHLastName
+	input.Group = subscriptionOptions.Group
+	if subscriptionOptions.ExpirationTracksChange {
+		input.ExpirationTracksNextBillingChange = FromBool(true)
+	}
+	if subscriptionOptions.ReceivesInvoiceEmails {
+		input.ReceivesInvoiceEmails = FromBool(true)
+	}
+	if subscriptionOptions.SnapDay != 0 || subscriptionOptions.CalendarBillingFirstDayCharge != "" {
+		input.CalendarBilling = &SubscriptionCalendarBilling{
+			CalendarBillingFirstCharge: subscriptionOptions.CalendarBillingFirstDayCharge,
 		}
-		if subscriptionOptions.CouponCode != "" {
-			body["subscription"]["coupon_code"] = subscriptionOptions.CouponCode
+		if subscriptionOptions.SnapDay != 0 {
+			input.CalendarBilling.SnapDay = fmt.Sprintf("%d", subscriptionOptions.SnapDay)
 		}
 	}
+	return input
+}
 
-	ret, err := makeCall(endpoints[endpointSubscriptionCreate], body, nil)
-	if err != nil {
-		return nil, err
-	}
-	// if successful, the subscription should come back in a map["subscription"]Subscription format
+// subscriptionFromResponse decodes a response that holds a single subscription in a map["subscription"]Subscription format
+func subscriptionFromResponse(ret APIReturn) (*Subscription, error) {
 	apiBody, bodyOK := ret.Body.(map[string]interface{})
 	if !bodyOK {
 		return nil, errors.New("could not understand server response")
 	}
 	subscription := &Subscription{}
-	err = mapstructure.Decode(apiBody["subscription"], subscription)
+	err := mapstructure.Decode(apiBody["subscription"], subscription)
 	return subscription, err
 }
 
-// CancelSubscription cancels a subscription. You can choose to cancel now or delay it. If you choose to delay, you can provide a reason code and message
+// CreateSubscriptionBilledToParent creates a new subscription for a customer that is invoiced to the customer's parent. The
+// customer must already have a parent. Billing options on subscriptionOptions.Group are kept, but its target is always the parent.
+func CreateSubscriptionBilledToParent(customerReference, productHandle string, paymentProfileID int64, subscriptionOptions *Subscription) (*Subscription, error) {
+	options := Subscription{}
+	if subscriptionOptions != nil {
+		options = *subscriptionOptions
+	}
+	group := SubscriptionGroupOptions{}
+	if options.Group != nil {
+		group = *options.Group
+	}
+	group.Target = SubscriptionGroupTarget{
+		Type: SubscriptionGroupTargetParent,
+	}
+	options.Group = &group
+	return CreateSubscriptionForCustomer(customerReference, productHandle, paymentProfileID, &options)
+}
+
+// CancelSubscriptionOptions are the options for canceling a subscription. Each field is sent when it is not empty.
+type CancelSubscriptionOptions struct {
+	Immediately         bool   // Cancel now instead of at the end of the current period
+	ReasonCode          string // (Optional) One of the reason codes configured for the site
+	CancellationMessage string // (Optional) A note about why the subscription was canceled
+	ValidateReasonCode  bool   // Check the reason code against the site's reason codes before canceling; see ValidateReasonCode
+}
+
+// CancelSubscription cancels a subscription. You can choose to cancel now or delay it, and can provide a reason code and message.
+// Use CancelSubscriptionWithOptions to validate the reason code first.
 func CancelSubscription(subscriptionID int64, cancelImmediately bool, reasonCode string, cancellationMessage string) error {
-	var err error
-	if cancelImmediately {
-		// it is a delete so no body
-		_, err = makeCall(endpoints[endpointSubscriptionCancelImmediately], nil, &map[string]string{
-			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
-		})
-	} else {
-		// if the reason info is set, then add it
-		reason := map[string]string{}
-		if reasonCode != "" && cancellationMessage != "" {
-			reason = map[string]string{
-				"cancellation_message": cancellationMessage,
-				"reason_code":          reasonCode,
-			}
+	return CancelSubscriptionWithOptions(subscriptionID, &CancelSubscriptionOptions{
+		Immediately:         cancelImmediately,
+		ReasonCode:          reasonCode,
+		CancellationMessage: cancellationMessage,
+	})
+}
+
+// CancelSubscriptionWithOptions cancels a subscription now or at the end of the current period
+func CancelSubscriptionWithOptions(subscriptionID int64, cancelOptions *CancelSubscriptionOptions) error {
+	if cancelOptions == nil {
+		cancelOptions = &CancelSubscriptionOptions{}
+	}
+	if cancelOptions.ValidateReasonCode {
+		if err := ValidateReasonCode(cancelOptions.ReasonCode); err != nil {
+			return err
 		}
-		_, err = makeCall(endpoints[endpointSubscriptionCancelDelayed], reason, &map[string]string{
-			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
-		})
 	}
+	_, err := makeAPICall(cancelOptions.toCallOptions(subscriptionID))
 	return err
 }
 
-// UpdateSubscription updates a subscription for a customer
+// toCallOptions builds the cancel call. Both the immediate and the delayed cancel take the reason wrapped in a subscription
+// object, and it is only sent when there is a reason.
+func (input *CancelSubscriptionOptions) toCallOptions(subscriptionID int64) *makeCallOptions {
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionCancelDelayed],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+	}
+	if input.Immediately {
+		options.End = endpoints[endpointSubscriptionCancelImmediately]
+	}
+	reason := map[string]string{}
+	if input.ReasonCode != "" {
+		reason["reason_code"] = input.ReasonCode
+	}
+	if input.CancellationMessage != "" {
+		reason["cancellation_message"] = input.CancellationMessage
+	}
+	if len(reason) > 0 {
+		options.Body = map[string]map[string]string{
+			"subscription": reason,
+		}
+	}
+	return options
+}
+
+// UpdateSubscription updates a subscription for a customer. The product change happens immediately without proration; use
+// ChangeSubscriptionProduct to control the timing and proration of the change.
 func UpdateSubscription(subscriptionID int64, productHandle string) error {
 	body := map[string]map[string]interface{}{
 		"subscription": {
@@ -203,6 +442,203 @@ func UpdateSubscription(subscriptionID int64, productHandle string) error {
 	return err
 }
 
+// ProrationCharge controls how the price difference is handled when a product change happens immediately
+type ProrationCharge string
+
+var (
+	// ProrationChargeProrated charges or credits the difference for the rest of the current period
+	ProrationChargeProrated ProrationCharge = "prorated"
+	// ProrationChargeFull charges or credits the full price of the new product
+	ProrationChargeFull ProrationCharge = "full"
+	// ProrationChargeNone makes no charge or credit
+	ProrationChargeNone ProrationCharge = "none"
+)
+
+// ChangeSubscriptionProductOptions are the options for changing the product of a subscription. The product must be specified
+// with one of ProductID or ProductHandle. When Delayed is true, the change happens at the next renewal and the proration
+// options cannot be used.
+type ChangeSubscriptionProductOptions struct {
+	ProductID               *int64
+	ProductHandle           string
+	ProductPricePointID     *int64          // (Optional) The price point of the new product to use instead of the default
+	ProductPricePointHandle string          // (Optional) The handle of the price point of the new product to use instead of the default
+	Delayed                 bool            // Schedule the change for the next renewal instead of making it now
+	IncludeTrial            *bool           // (Optional, immediate only) Start the trial of the new product, if it has one
+	UpgradeCharge           ProrationCharge // (Optional, immediate only) How to charge for an upgrade
+	DowngradeCredit         ProrationCharge // (Optional, immediate only) How to credit a downgrade
+}
+
+func (input *ChangeSubscriptionProductOptions) validate() error {
+	if (input.ProductID == nil) == (input.ProductHandle == "") {
+		return errors.New("exactly one of product id or product handle is required")
+	}
+	if input.ProductPricePointID != nil && input.ProductPricePointHandle != "" {
+		return errors.New("only one of product price point id or product price point handle may be provided")
+	}
+	if input.Delayed && (input.IncludeTrial != nil || input.UpgradeCharge != "" || input.DowngradeCredit != "") {
+		return errors.New("proration options cannot be used with a delayed product change")
+	}
+	return nil
+}
+
+// productFields are the fields that identify the new product for a delayed change
+func (input *ChangeSubscriptionProductOptions) productFields() map[string]interface{} {
+	fields := map[string]interface{}{}
+	if input.ProductID != nil {
+		fields["product_id"] = ToInt64(input.ProductID)
+	}
+	if input.ProductHandle != "" {
+		fields["product_handle"] = input.ProductHandle
+	}
+	if input.ProductPricePointID != nil {
+		fields["product_price_point_id"] = ToInt64(input.ProductPricePointID)
+	}
+	if input.ProductPricePointHandle != "" {
+		fields["product_price_point_handle"] = input.ProductPricePointHandle
+	}
+	return fields
+}
+
+// ChangeSubscriptionProduct changes the product of a subscription. A delayed change is scheduled for the next renewal and
+// can be cancelled with CancelDelayedProductChange. An immediate change is made through MigrateSubscriptionWithOptions, so
+// that the proration options can be applied.
+func ChangeSubscriptionProduct(subscriptionID int64, changeOptions *ChangeSubscriptionProductOptions) (*Subscription, error) {
+	if changeOptions == nil {
+		return nil, errors.New("options are required")
+	}
+	if err := changeOptions.validate(); err != nil {
+		return nil, err
+	}
+	if !changeOptions.Delayed {
+		return MigrateSubscriptionWithOptions(subscriptionID, &MigrationOptions{
+			ProductID:               changeOptions.ProductID,
+			ProductHandle:           changeOptions.ProductHandle,
+			ProductPricePointID:     changeOptions.ProductPricePointID,
+			ProductPricePointHandle: changeOptions.ProductPricePointHandle,
+			IncludeTrial:            changeOptions.IncludeTrial,
+			UpgradeCharge:           changeOptions.UpgradeCharge,
+			DowngradeCredit:         changeOptions.DowngradeCredit,
+		})
+	}
+	fields := changeOptions.productFields()
+	fields["product_change_delayed"] = true
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionUpdate],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: map[string]map[string]interface{}{
+			"subscription": fields,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionFromResponse(ret)
+}
+
+// CancelDelayedProductChange cancels a pending delayed product change, keeping the subscription on its current product
+func CancelDelayedProductChange(subscriptionID int64) (*Subscription, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionUpdate],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: map[string]map[string]string{
+			"subscription": {
+				"next_product_id": "",
+			},
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionFromResponse(ret)
+}
+
+// OverrideSubscription overrides the historic dates of a subscription, such as when importing subscriptions from another
+// system. Only ActivatedAt, CanceledAt, CancellationMessage, ExpiresAt, and CurrentPeriodStartedAt are used, and only
+// when they are not empty. No charges, emails, or webhooks result from an override.
+func OverrideSubscription(subscriptionID int64, input *Subscription) error {
+	if input == nil {
+		return errors.New("input is required")
+	}
+	override := map[string]string{}
+	if input.ActivatedAt != "" {
+		override["activated_at"] = input.ActivatedAt
+	}
+	if input.CanceledAt != "" {
+		override["canceled_at"] = input.CanceledAt
+	}
+	if input.CancellationMessage != "" {
+		override["cancellation_message"] = input.CancellationMessage
+	}
+	if input.ExpiresAt != "" {
+		override["expires_at"] = input.ExpiresAt
+	}
+	if input.CurrentPeriodStartedAt != "" {
+		override["current_period_starts_at"] = input.CurrentPeriodStartedAt
+	}
+	if len(override) == 0 {
+		return errors.New("at least one field to override is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionOverride],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: map[string]map[string]string{
+			"subscription": override,
+		},
+	}
+	_, err := makeAPICall(options)
+	return err
+}
+
+// SubscriptionBillingUpdate holds the billing date fields of a subscription that can be changed after it is created. Only
+// the fields that are not nil are sent.
+type SubscriptionBillingUpdate struct {
+	NextBillingAt                     *string `json:"next_billing_at,omitempty"`                       // Move the next renewal to this date, without any charges or credits
+	SnapDay                           *string `json:"snap_day,omitempty"`                              // Align renewals to a day of the month, between 1 and 28, or end
+	ExpiresAt                         *string `json:"expires_at,omitempty"`                            // The date the subscription expires
+	ExpirationTracksNextBillingChange *bool   `json:"expiration_tracks_next_billing_change,omitempty"` // Shift expires_at along with changes to next_billing_at
+}
+
+// UpdateSubscriptionBilling changes the billing dates of a subscription and returns the updated subscription
+func UpdateSubscriptionBilling(subscriptionID int64, input *SubscriptionBillingUpdate) (*Subscription, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	return updateSubscriptionFields(subscriptionID, input)
+}
+
+// ResetSubscriptionSnapDay removes the calendar billing from a subscription, so that it renews on its regular interval from
+// the next billing date
+func ResetSubscriptionSnapDay(subscriptionID int64) (*Subscription, error) {
+	return updateSubscriptionFields(subscriptionID, map[string]interface{}{
+		"snap_day": nil,
+	})
+}
+
+func updateSubscriptionFields(subscriptionID int64, fields interface{}) (*Subscription, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionUpdate],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: map[string]interface{}{
+			"subscription": fields,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionFromResponse(ret)
+}
+
 // RemoveDelayedSubscriptionCancellation removes a delayed cancellation request, ensuring the subscription does not cancel
 func RemoveDelayedSubscriptionCancellation(subscriptionID int64) error {
 	_, err := makeCall(endpoints[endpointSubscriptionRemoveDelayedCancel], nil, &map[string]string{
@@ -211,24 +647,286 @@ func RemoveDelayedSubscriptionCancellation(subscriptionID int64) error {
 	return err
 }
 
-// MigrateSubscription migrates an existing subscription to a new subscription
-func MigrateSubscription(targetProductHandle string, currentSubscriptionID int64, includeTrial bool, includeInitialCharge bool, includeCoupons bool, preservePeriod bool) error {
-	body := map[string]map[string]interface{}{
-		"migration": {
-			"product_handle":         targetProductHandle,
-			"include_trial":          includeTrial,
-			"include_initial_charge": includeInitialCharge,
-			"include_coupons":        includeCoupons,
-			"preserve_period":        preservePeriod,
+// SubscriptionResumptionCharge controls how a subscription with calendar billing is charged when it resumes
+type SubscriptionResumptionCharge string
+
+var (
+	// SubscriptionResumptionChargeProrated charges the prorated price up to the next calendar billing date
+	SubscriptionResumptionChargeProrated SubscriptionResumptionCharge = "prorated"
+	// SubscriptionResumptionChargeImmediate charges the full price immediately
+	SubscriptionResumptionChargeImmediate SubscriptionResumptionCharge = "immediate"
+	// SubscriptionResumptionChargeDelayed charges the full price at the next calendar billing date
+	SubscriptionResumptionChargeDelayed SubscriptionResumptionCharge = "delayed"
+)
+
+// HoldSubscription puts a subscription on hold, pausing it until it is resumed. If automaticallyResumeAt is not empty, the
+// subscription will resume on its own at that time.
+func HoldSubscription(subscriptionID int64, automaticallyResumeAt string) (*Subscription, error) {
+	return saveSubscriptionHold(endpointSubscriptionHold, subscriptionID, automaticallyResumeAt)
+}
+
+// UpdateSubscriptionHold changes the automatic resume date of a subscription that is already on hold. Passing in an empty
+// automaticallyResumeAt removes the date, leaving the subscription on hold until it is resumed manually.
+func UpdateSubscriptionHold(subscriptionID int64, automaticallyResumeAt string) (*Subscription, error) {
+	return saveSubscriptionHold(endpointSubscriptionHoldUpdate, subscriptionID, automaticallyResumeAt)
+}
+
+func saveSubscriptionHold(end string, subscriptionID int64, automaticallyResumeAt string) (*Subscription, error) {
+	hold := map[string]interface{}{}
+	if automaticallyResumeAt != "" {
+		hold["automatically_resume_at"] = automaticallyResumeAt
+	} else if end == endpointSubscriptionHoldUpdate {
+		hold["automatically_resume_at"] = nil
+	}
+	options := &makeCallOptions{
+		End: endpoints[end],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: map[string]map[string]interface{}{
+			"hold": hold,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionFromResponse(ret)
+}
+
+// ResumeSubscription resumes a subscription that is on hold. The resumptionCharge only applies to subscriptions with calendar
+// billing and may be left empty to use the site default.
+func ResumeSubscription(subscriptionID int64, resumptionCharge SubscriptionResumptionCharge) (*Subscription, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionResume],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+	}
+	if resumptionCharge != "" {
+		options.QueryParams = &map[string]string{
+			"calendar_billing[resumption_charge]": string(resumptionCharge),
+		}
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionFromResponse(ret)
+}
+
+// ReactivateSubscriptionOptions are the options for reactivating a subscription. Every field is optional.
+type ReactivateSubscriptionOptions struct {
+	IncludeTrial             *bool                        // Start a new trial period, if the product has one
+	PreserveBalance          *bool                        // Keep any balance left on the subscription when it was canceled
+	CouponCode               string                       // A coupon to apply to the reactivated subscription
+	UseCreditsAndPrepayments *bool                        // Apply existing credits and prepayments to the reactivation charge
+	ReactivationCharge       SubscriptionResumptionCharge // For calendar billing, one of prorated, immediate, or delayed
+	// Resume only applies to subscriptions that were canceled at the end of the period and are still in their final
+	// period. When true, the subscription resumes the current period instead of starting a new one.
+	Resume *bool
+	// RequireResume fails the reactivation if the subscription cannot be resumed, rather than starting a new period. It
+	// implies resuming, so it cannot be used when Resume is false.
+	RequireResume *bool
+	// ForgiveBalance forgives any outstanding balance when resuming. It implies resuming, so it cannot be used when Resume is false.
+	ForgiveBalance *bool
+}
+
+func (input *ReactivateSubscriptionOptions) toBody() (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+	if input == nil {
+		return body, nil
+	}
+	if input.IncludeTrial != nil {
+		body["include_trial"] = ToBool(input.IncludeTrial)
+	}
+	if input.PreserveBalance != nil {
+		body["preserve_balance"] = ToBool(input.PreserveBalance)
+	}
+	if input.CouponCode != "" {
+		body["coupon_code"] = input.CouponCode
+	}
+	if input.UseCreditsAndPrepayments != nil {
+		body["use_credits_and_prepayments"] = ToBool(input.UseCreditsAndPrepayments)
+	}
+	if input.ReactivationCharge != "" {
+		body["calendar_billing"] = map[string]string{
+			"reactivation_charge": string(input.ReactivationCharge),
+		}
+	}
+	// resume may be a plain boolean, or an object when the extra resume options are used; Chargify treats the object as
+	// a request to resume, so it cannot be combined with Resume set to false
+	if input.RequireResume != nil || input.ForgiveBalance != nil {
+		if input.Resume != nil && !ToBool(input.Resume) {
+			return nil, errors.New("require resume and forgive balance cannot be used when resume is false")
+		}
+		resume := map[string]bool{}
+		if input.RequireResume != nil {
+			resume["require_resume"] = ToBool(input.RequireResume)
+		}
+		if input.ForgiveBalance != nil {
+			resume["forgive_balance"] = ToBool(input.ForgiveBalance)
+		}
+		body["resume"] = resume
+	} else if input.Resume != nil {
+		body["resume"] = ToBool(input.Resume)
+	}
+	return body, nil
+}
+
+// ReactivateSubscription reactivates a canceled, expired, or trial ended subscription. The options may be nil to use the
+// Chargify defaults.
+func ReactivateSubscription(subscriptionID int64, reactivateOptions *ReactivateSubscriptionOptions) (*Subscription, error) {
+	body, err := reactivateOptions.toBody()
+	if err != nil {
+		return nil, err
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionReactivate],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
 		},
+		Body: body,
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
 	}
+	return subscriptionFromResponse(ret)
+}
+
+// MigrationOptions are the options for migrating a subscription to a different product. The product must be specified with
+// one of ProductID or ProductHandle; every other field is optional.
+type MigrationOptions struct {
+	ProductID               *int64
+	ProductHandle           string
+	ProductPricePointID     *int64          // The price point of the new product to use instead of the default
+	ProductPricePointHandle string          // The handle of the price point of the new product to use instead of the default
+	IncludeTrial            *bool           // Start the trial of the new product, if it has one
+	IncludeInitialCharge    *bool           // Charge the initial charge of the new product
+	IncludeCoupons          *bool           // Keep the coupons currently applied to the subscription
+	PreservePeriod          *bool           // Keep the current billing period instead of starting a new one
+	UpgradeCharge           ProrationCharge // How to charge for an upgrade
+	DowngradeCredit         ProrationCharge // How to credit a downgrade
+}
+
+// MigrationPreview is the result of previewing a migration. All amounts are in integer cents.
+type MigrationPreview struct {
+	ProratedAdjustmentInCents int64 `json:"prorated_adjustment_in_cents" mapstructure:"prorated_adjustment_in_cents"` // The prorated adjustment for the unused portion of the current period
+	ChargeInCents             int64 `json:"charge_in_cents" mapstructure:"charge_in_cents"`                           // The charge for the new product
+	PaymentDueInCents         int64 `json:"payment_due_in_cents" mapstructure:"payment_due_in_cents"`                 // The amount that will be collected
+	CreditAppliedInCents      int64 `json:"credit_applied_in_cents" mapstructure:"credit_applied_in_cents"`           // The existing credit that will be applied
+}
 
-	_, err := makeCall(endpoints[endpointSubscriptionMigrate], body, &map[string]string{
-		"subscriptionID": fmt.Sprintf("%d", currentSubscriptionID),
+func (input *MigrationOptions) toBody() (map[string]map[string]interface{}, error) {
+	if input == nil {
+		return nil, errors.New("options are required")
+	}
+	if (input.ProductID == nil) == (input.ProductHandle == "") {
+		return nil, errors.New("exactly one of product id or product handle is required")
+	}
+	if input.ProductPricePointID != nil && input.ProductPricePointHandle != "" {
+		return nil, errors.New("only one of product price point id or product price point handle may be provided")
+	}
+	migration := map[string]interface{}{}
+	if input.ProductID != nil {
+		migration["product_id"] = ToInt64(input.ProductID)
+	}
+	if input.ProductHandle != "" {
+		migration["product_handle"] = input.ProductHandle
+	}
+	if input.ProductPricePointID != nil {
+		migration["product_price_point_id"] = ToInt64(input.ProductPricePointID)
+	}
+	if input.ProductPricePointHandle != "" {
+		migration["product_price_point_handle"] = input.ProductPricePointHandle
+	}
+	if input.IncludeTrial != nil {
+		migration["include_trial"] = ToBool(input.IncludeTrial)
+	}
+	if input.IncludeInitialCharge != nil {
+		migration["include_initial_charge"] = ToBool(input.IncludeInitialCharge)
+	}
+	if input.IncludeCoupons != nil {
+		migration["include_coupons"] = ToBool(input.IncludeCoupons)
+	}
+	if input.PreservePeriod != nil {
+		migration["preserve_period"] = ToBool(input.PreservePeriod)
+	}
+	if input.UpgradeCharge != "" || input.DowngradeCredit != "" {
+		proration := map[string]string{}
+		if input.UpgradeCharge != "" {
+			proration["upgrade_charge"] = string(input.UpgradeCharge)
+		}
+		if input.DowngradeCredit != "" {
+			proration["downgrade_credit"] = string(input.DowngradeCredit)
+		}
+		migration["proration"] = proration
+	}
+	return map[string]map[string]interface{}{
+		"migration": migration,
+	}, nil
+}
+
+// MigrateSubscription migrates an existing subscription to a new subscription. Use MigrateSubscriptionWithOptions for price
+// points, proration, and the resulting subscription.
+func MigrateSubscription(targetProductHandle string, currentSubscriptionID int64, includeTrial bool, includeInitialCharge bool, includeCoupons bool, preservePeriod bool) error {
+	_, err := MigrateSubscriptionWithOptions(currentSubscriptionID, &MigrationOptions{
+		ProductHandle:        targetProductHandle,
+		IncludeTrial:         &includeTrial,
+		IncludeInitialCharge: &includeInitialCharge,
+		IncludeCoupons:       &includeCoupons,
+		PreservePeriod:       &preservePeriod,
 	})
 	return err
 }
 
+// MigrateSubscriptionWithOptions migrates a subscription to a different product immediately and returns the migrated subscription
+func MigrateSubscriptionWithOptions(subscriptionID int64, migrationOptions *MigrationOptions) (*Subscription, error) {
+	body, err := migrationOptions.toBody()
+	if err != nil {
+		return nil, err
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionMigrate],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: body,
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionFromResponse(ret)
+}
+
+// PreviewMigration previews the charges and credits of migrating a subscription, without making any changes
+func PreviewMigration(subscriptionID int64, migrationOptions *MigrationOptions) (*MigrationPreview, error) {
+	body, err := migrationOptions.toBody()
+	if err != nil {
+		return nil, err
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionMigratePreview],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: body,
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	preview := &MigrationPreview{}
+	err = mapstructure.Decode(apiBody["migration"], preview)
+	return preview, err
+}
+
 // GetSubscription gets a subscription. The docs show it comes back as an array, but as of this implementation it comes back as a map
 func GetSubscription(subscriptionID int64) (*Subscription, error) {
 	ret, err := makeCall(endpoints[endpointSubscriptionGet], nil, &map[string]string{
@@ -247,11 +945,11 @@ func GetSubscription(subscriptionID int64) (*Subscription, error) {
 	return subscription, err
 }
 
-// GetProductFamilyProducts gets products in a family
+// GetSubscriptionComponents gets the components of a subscription, along with their current allocations
 func GetSubscriptionComponents(subscriptionID int64) ([]SubscriptionComponent, error) {
 	found := []SubscriptionComponent{}
 
-	ret, err := makeCall(endpoints[endpointProductFamilyComponentsGet], nil, &map[string]string{
+	ret, err := makeCall(endpoints[endpointSubscriptionComponentsGet], nil, &map[string]string{
 		"subscriptionID": fmt.Sprintf("%d", subscriptionID),
 	})
 	if err != nil || ret.HTTPCode != http.StatusOK {
@@ -291,6 +989,161 @@ func GetSubscriptionMetaData(subscriptionID int64) (*MetaData, error) {
 	return data, err
 }
 
+// CreateSubscriptionMetaData sets new metadata on a subscription. Only the Name and Value of each entry are sent.
+func CreateSubscriptionMetaData(subscriptionID int64, entries []MetaDataEntry) ([]MetaDataEntry, error) {
+	return saveMetaData(endpointMetaDataCreate, MetaDataResourceSubscriptions, subscriptionID, entries)
+}
+
+// UpdateSubscriptionMetaData updates existing metadata on a subscription. Only the Name and Value of each entry are sent.
+func UpdateSubscriptionMetaData(subscriptionID int64, entries []MetaDataEntry) ([]MetaDataEntry, error) {
+	return saveMetaData(endpointMetaDataUpdate, MetaDataResourceSubscriptions, subscriptionID, entries)
+}
+
+// DeleteSubscriptionMetaData removes the metadata with the passed in names from a subscription
+func DeleteSubscriptionMetaData(subscriptionID int64, names ...string) error {
+	return deleteMetaData(MetaDataResourceSubscriptions, subscriptionID, names)
+}
+
+// SubscriptionNote is a note left on a subscription, such as by a support agent
+type SubscriptionNote struct {
+	ID             int64  `json:"id,omitempty" mapstructure:"id"`
+	SubscriptionID int64  `json:"subscription_id,omitempty" mapstructure:"subscription_id"`
+	Body           string `json:"body" mapstructure:"body"`     // The text of the note
+	Sticky         bool   `json:"sticky" mapstructure:"sticky"` // Whether the note is pinned to the top of the subscription in the Chargify UI
+	CreatedAt      string `json:"created_at,omitempty" mapstructure:"created_at"`
+	UpdatedAt      string `json:"updated_at,omitempty" mapstructure:"updated_at"`
+}
+
+// ListSubscriptionNotes lists the notes on a subscription
+func ListSubscriptionNotes(subscriptionID int64, page int, perPage int) ([]SubscriptionNote, error) {
+	if page < 1 {
+		return nil, errors.New("page must be 1 or higher, not 0 indexed")
+	}
+	queryParams := map[string]string{
+		"page": fmt.Sprintf("%d", page),
+	}
+	if perPage > 0 {
+		queryParams["per_page"] = fmt.Sprintf("%d", perPage)
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionNotesList],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		QueryParams: &queryParams,
+	}
+
+	data := []SubscriptionNote{}
+
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return data, err
+	}
+	apiBody, bodyOK := ret.Body.([]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	// the result is an array of objects that have a note key
+	for i := range apiBody {
+		if raw, rawOK := apiBody[i].(map[string]interface{}); rawOK {
+			note := SubscriptionNote{}
+			err = mapstructure.Decode(raw["note"], &note)
+			if err == nil {
+				data = append(data, note)
+			}
+		}
+	}
+	return data, nil
+}
+
+// GetSubscriptionNote gets a single note on a subscription
+func GetSubscriptionNote(subscriptionID int64, noteID int64) (*SubscriptionNote, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionNoteGet],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+			"noteID":         fmt.Sprintf("%d", noteID),
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	note := &SubscriptionNote{}
+	err = decodeSubscriptionNote(ret, note)
+	return note, err
+}
+
+// CreateSubscriptionNote creates a note on a subscription. The result is placed in the input.
+func CreateSubscriptionNote(subscriptionID int64, input *SubscriptionNote) error {
+	if input.Body == "" {
+		return errors.New("body is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionNoteCreate],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		},
+		Body: map[string]map[string]interface{}{
+			"note": {
+				"body":   input.Body,
+				"sticky": input.Sticky,
+			},
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return err
+	}
+	return decodeSubscriptionNote(ret, input)
+}
+
+// UpdateSubscriptionNote updates the body and sticky flag of a note on a subscription. The result is placed in the input.
+func UpdateSubscriptionNote(subscriptionID int64, input *SubscriptionNote) error {
+	if input.ID == 0 || input.Body == "" {
+		return errors.New("id and body are required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionNoteUpdate],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+			"noteID":         fmt.Sprintf("%d", input.ID),
+		},
+		Body: map[string]map[string]interface{}{
+			"note": {
+				"body":   input.Body,
+				"sticky": input.Sticky,
+			},
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return err
+	}
+	return decodeSubscriptionNote(ret, input)
+}
+
+// DeleteSubscriptionNote deletes a note from a subscription
+func DeleteSubscriptionNote(subscriptionID int64, noteID int64) error {
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionNoteDelete],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+			"noteID":         fmt.Sprintf("%d", noteID),
+		},
+	}
+	_, err := makeAPICall(options)
+	return err
+}
+
+func decodeSubscriptionNote(ret APIReturn, note *SubscriptionNote) error {
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return errors.New("could not understand server response")
+	}
+	return mapstructure.Decode(apiBody["note"], note)
+}
+
 // RefundSubscriptionPayment refunds a specific payment for a subscription. This is supposedly deprecated to support relationship
 // invoicing
 func RefundSubscriptionPayment(subscriptionID string, paymentID string, amount string, memo string) (*Refund, error) {
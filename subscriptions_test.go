@@ -1,7 +1,9 @@
 package chargify
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -29,6 +31,50 @@ func TestSubscriptionCRUD(t *testing.T) {
 	err = UpdateSubscription(subscription.ID, "33")
 	assert.Nil(t, err)
 
+	// the subscription was created without components, so none should come back
+	components, err := GetSubscriptionComponents(subscription.ID)
+	require.Nil(t, err)
+	assert.Equal(t, 0, len(components))
+
+	note := &SubscriptionNote{Body: "Called about an upgrade", Sticky: true}
+	err = CreateSubscriptionNote(subscription.ID, note)
+	require.Nil(t, err)
+	require.NotZero(t, note.ID)
+	note.Body = "Called about an upgrade; follow up next week"
+	err = UpdateSubscriptionNote(subscription.ID, note)
+	require.Nil(t, err)
+	foundNote, err := GetSubscriptionNote(subscription.ID, note.ID)
+	require.Nil(t, err)
+	assert.Equal(t, note.Body, foundNote.Body)
+	notes, err := ListSubscriptionNotes(subscription.ID, 1, 0)
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(notes))
+	err = DeleteSubscriptionNote(subscription.ID, note.ID)
+	assert.Nil(t, err)
+
+	nextBillingAt := time.Now().AddDate(0, 2, 0).Format(time.RFC3339)
+	updated, err := UpdateSubscriptionBilling(subscription.ID, &SubscriptionBillingUpdate{
+		NextBillingAt: &nextBillingAt,
+	})
+	require.Nil(t, err)
+	assert.NotEmpty(t, updated.NextBillingAt)
+
+	err = OverrideSubscription(subscription.ID, &Subscription{
+		ActivatedAt: time.Now().AddDate(-1, 0, 0).Format(time.RFC3339),
+	})
+	assert.Nil(t, err)
+
+	held, err := HoldSubscription(subscription.ID, "")
+	require.Nil(t, err)
+	assert.Equal(t, SubscriptionStateOnHold, held.State)
+
+	_, err = UpdateSubscriptionHold(subscription.ID, time.Now().AddDate(0, 1, 0).Format(time.RFC3339))
+	assert.Nil(t, err)
+
+	resumed, err := ResumeSubscription(subscription.ID, "")
+	require.Nil(t, err)
+	assert.NotEqual(t, SubscriptionStateOnHold, resumed.State)
+
 	err = CancelSubscription(subscription.ID, false, "MY_REASON", "Testing")
 	require.Nil(t, err)
 
@@ -37,4 +83,200 @@ func TestSubscriptionCRUD(t *testing.T) {
 
 	err = CancelSubscription(subscription.ID, true, "MY_REASON", "Testing")
 	require.Nil(t, err)
+
+	reactivated, err := ReactivateSubscription(subscription.ID, &ReactivateSubscriptionOptions{
+		PreserveBalance: FromBool(true),
+	})
+	require.Nil(t, err)
+	assert.True(t, reactivated.State.IsLive())
+
+	err = CancelSubscription(subscription.ID, true, "MY_REASON", "Testing")
+	require.Nil(t, err)
+}
+
+func TestChangeSubscriptionProductOptions(t *testing.T) {
+	input := &ChangeSubscriptionProductOptions{}
+	assert.NotNil(t, input.validate())
+	input.ProductHandle = "handle"
+	assert.Nil(t, input.validate())
+	input.ProductID = FromInt64(1)
+	assert.NotNil(t, input.validate())
+	input.ProductID = nil
+	input.ProductPricePointID = FromInt64(2)
+	input.UpgradeCharge = ProrationChargeFull
+	assert.Nil(t, input.validate())
+	input.Delayed = true
+	assert.NotNil(t, input.validate())
+
+	assert.Equal(t, map[string]interface{}{
+		"product_handle":         "handle",
+		"product_price_point_id": int64(2),
+	}, input.productFields())
+
+	_, err := ChangeSubscriptionProduct(1, nil)
+	assert.NotNil(t, err)
+}
+
+func TestCancelSubscriptionOptions(t *testing.T) {
+	for _, immediately := range []bool{true, false} {
+		input := &CancelSubscriptionOptions{
+			Immediately:         immediately,
+			ReasonCode:          "MY_REASON",
+			CancellationMessage: "Testing",
+		}
+		options := input.toCallOptions(5)
+		assert.Equal(t, map[string]string{"subscriptionID": "5"}, *options.PathParams)
+		assert.Equal(t, map[string]map[string]string{
+			"subscription": {"reason_code": "MY_REASON", "cancellation_message": "Testing"},
+		}, options.Body)
+		if immediately {
+			assert.Equal(t, endpoints[endpointSubscriptionCancelImmediately], options.End)
+		} else {
+			assert.Equal(t, endpoints[endpointSubscriptionCancelDelayed], options.End)
+		}
+
+		// without a reason, nothing is sent
+		input.ReasonCode = ""
+		input.CancellationMessage = ""
+		assert.Nil(t, input.toCallOptions(5).Body)
+	}
+}
+
+func TestMigrationOptions(t *testing.T) {
+	var nilOptions *MigrationOptions
+	_, err := nilOptions.toBody()
+	assert.NotNil(t, err)
+	_, err = (&MigrationOptions{}).toBody()
+	assert.NotNil(t, err)
+	_, err = (&MigrationOptions{ProductHandle: "handle", ProductPricePointID: FromInt64(1), ProductPricePointHandle: "pp"}).toBody()
+	assert.NotNil(t, err)
+
+	body, err := (&MigrationOptions{
+		ProductID:      FromInt64(10),
+		PreservePeriod: FromBool(false),
+		UpgradeCharge:  ProrationChargeProrated,
+	}).toBody()
+	require.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"product_id":      int64(10),
+		"preserve_period": false,
+		"proration":       map[string]string{"upgrade_charge": "prorated"},
+	}, body["migration"])
+}
+
+func TestReactivateSubscriptionOptions(t *testing.T) {
+	var nilOptions *ReactivateSubscriptionOptions
+	body, err := nilOptions.toBody()
+	require.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{}, body)
+
+	body, err = (&ReactivateSubscriptionOptions{
+		IncludeTrial:       FromBool(false),
+		CouponCode:         "BACK",
+		ReactivationCharge: SubscriptionResumptionChargeDelayed,
+		Resume:             FromBool(true),
+	}).toBody()
+	require.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"include_trial":    false,
+		"coupon_code":      "BACK",
+		"calendar_billing": map[string]string{"reactivation_charge": "delayed"},
+		"resume":           true,
+	}, body)
+
+	// the extra resume options switch resume to an object
+	body, err = (&ReactivateSubscriptionOptions{
+		Resume:         FromBool(true),
+		ForgiveBalance: FromBool(true),
+	}).toBody()
+	require.Nil(t, err)
+	assert.Equal(t, map[string]bool{"forgive_balance": true}, body["resume"])
+
+	// which would resume the subscription, so they cannot be used when resume is false
+	_, err = (&ReactivateSubscriptionOptions{
+		Resume:         FromBool(false),
+		ForgiveBalance: FromBool(true),
+	}).toBody()
+	assert.NotNil(t, err)
+	_, err = ReactivateSubscription(1, &ReactivateSubscriptionOptions{
+		Resume:        FromBool(false),
+		RequireResume: FromBool(true),
+	})
+	assert.NotNil(t, err)
+}
+
+func TestCreateSubscriptionInput(t *testing.T) {
+	// validation
+	input := &CreateSubscriptionInput{}
+	assert.NotNil(t, input.validate(true))
+	input.ProductHandle = "handle"
+	assert.Nil(t, input.validate(false))
+	input.ProductHandle = ""
+	input.CustomerReference = "ref"
+	assert.NotNil(t, input.validate(true))
+	input.ProductHandle = "handle"
+	assert.Nil(t, input.validate(true))
+	input.CustomerID = FromInt64(1)
+	assert.NotNil(t, input.validate(true))
+	input.CustomerID = nil
+	input.ProductID = FromInt64(1)
+	assert.NotNil(t, input.validate(true))
+	input.ProductID = nil
+	input.ProductPricePointHandle = "pp"
+	input.CustomPrice = &SubscriptionCustomPrice{PriceInCents: 100, IntervalUnit: ProductIntervalMonth, Interval: 1}
+	assert.NotNil(t, input.validate(true))
+	input.ProductPricePointHandle = ""
+	assert.Nil(t, input.validate(true))
+	input.NextBillingAt = "2030-01-01"
+	input.CalendarBilling = &SubscriptionCalendarBilling{SnapDay: "1"}
+	assert.NotNil(t, input.validate(true))
+
+	// only the fields that are set should be sent
+	encoded, err := json.Marshal(&CreateSubscriptionInput{
+		CustomerReference: "ref",
+		ProductHandle:     "handle",
+		CouponCodes:       []string{"A", "B"},
+		Components: []SubscriptionComponentInput{
+			{ComponentID: 1, AllocatedQuantity: FromInt64(5)},
+		},
+		ReceivesInvoiceEmails: FromBool(false),
+	})
+	require.Nil(t, err)
+	decoded := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, 5, len(decoded))
+	assert.Equal(t, "ref", decoded["customer_reference"])
+	assert.Equal(t, "handle", decoded["product_handle"])
+	assert.Equal(t, false, decoded["receives_invoice_emails"])
+	assert.Equal(t, []interface{}{"A", "B"}, decoded["coupon_codes"])
+	components := decoded["components"].([]interface{})
+	require.Equal(t, 1, len(components))
+	assert.Equal(t, map[string]interface{}{"component_id": float64(1), "allocated_quantity": float64(5)}, components[0])
+
+	// the legacy options should map onto the input
+	legacy := newCreateSubscriptionInput("ref", "handle", 12, &Subscription{
+		CouponCode:            "CODE",
+		SnapDay:               15,
+		ReceivesInvoiceEmails: true,
+	})
+	assert.Equal(t, int64(12), ToInt64(legacy.PaymentProfileID))
+	assert.Equal(t, []string{"CODE"}, legacy.CouponCodes)
+	require.NotNil(t, legacy.CalendarBilling)
+	assert.Equal(t, "15", legacy.CalendarBilling.SnapDay)
+	assert.True(t, ToBool(legacy.ReceivesInvoiceEmails))
+	assert.Nil(t, newCreateSubscriptionInput("ref", "handle", 0, nil).PaymentProfileID)
+}
+
+func TestSubscriptionBillingUpdate(t *testing.T) {
+	assert.NotNil(t, OverrideSubscription(1, nil))
+	assert.NotNil(t, OverrideSubscription(1, &Subscription{NextBillingAt: "2030-01-01"}))
+	_, err := UpdateSubscriptionBilling(1, nil)
+	assert.NotNil(t, err)
+
+	encoded, err := json.Marshal(&SubscriptionBillingUpdate{
+		SnapDay:                           FromString("end"),
+		ExpirationTracksNextBillingChange: FromBool(false),
+	})
+	require.Nil(t, err)
+	assert.Equal(t, `{"snap_day":"end","expiration_tracks_next_billing_change":false}`, string(encoded))
 }
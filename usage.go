@@ -0,0 +1,332 @@
+package chargify
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// Usage is a quantity of metered usage recorded against a component on a subscription
+type Usage struct {
+	ID              int64   `json:"id" mapstructure:"id"`
+	Quantity        float64 `json:"quantity" mapstructure:"quantity"` // The quantity used; may be fractional for components that allow it
+	OverageQuantity float64 `json:"overage_quantity" mapstructure:"overage_quantity"`
+	Memo            string  `json:"memo" mapstructure:"memo"`
+	CreatedAt       string  `json:"created_at" mapstructure:"created_at"`
+	PricePointID    int64   `json:"price_point_id" mapstructure:"price_point_id"`
+	ComponentID     int64   `json:"component_id" mapstructure:"component_id"`
+	ComponentHandle string  `json:"component_handle" mapstructure:"component_handle"`
+	SubscriptionID  int64   `json:"subscription_id" mapstructure:"subscription_id"`
+}
+
+// ListUsagesQueryParams are the query parameters for listing the usages of a component
+type ListUsagesQueryParams struct {
+	SinceID   *int64  `json:"since_id"`   // Only usages with an id greater than or equal to this
+	MaxID     *int64  `json:"max_id"`     // Only usages with an id less than or equal to this
+	SinceDate *string `json:"since_date"` // Only usages on or after this date, in YYYY-MM-DD format
+	UntilDate *string `json:"until_date"` // Only usages on or before this date, in YYYY-MM-DD format
+	Page      *int    `json:"page"`
+	PerPage   *int    `json:"per_page"`
+}
+
+func (input *ListUsagesQueryParams) toMap() *map[string]string {
+	m := map[string]string{}
+	if input.SinceID != nil {
+		m["since_id"] = fmt.Sprintf("%d", ToInt64(input.SinceID))
+	}
+	if input.MaxID != nil {
+		m["max_id"] = fmt.Sprintf("%d", ToInt64(input.MaxID))
+	}
+	if input.SinceDate != nil {
+		m["since_date"] = ToString(input.SinceDate)
+	}
+	if input.UntilDate != nil {
+		m["until_date"] = ToString(input.UntilDate)
+	}
+	if input.Page != nil {
+		m["page"] = fmt.Sprintf("%d", ToInt(input.Page))
+	}
+	if input.PerPage != nil {
+		m["per_page"] = fmt.Sprintf("%d", ToInt(input.PerPage))
+	}
+	return &m
+}
+
+// RecordUsage records metered usage against a component on a subscription. The memo is optional.
+func RecordUsage(subscriptionID int64, componentID int64, quantity float64, memo string) (*Usage, error) {
+	usage := map[string]interface{}{
+		"quantity": quantity,
+	}
+	if memo != "" {
+		usage["memo"] = memo
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionComponentsUsages],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+			"componentID":    fmt.Sprintf("%d", componentID),
+		},
+		Body: map[string]map[string]interface{}{
+			"usage": usage,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	return decodeUsage(apiBody["usage"])
+}
+
+// ListUsages lists the usage recorded against a component on a subscription
+func ListUsages(subscriptionID int64, componentID int64, params *ListUsagesQueryParams) ([]Usage, error) {
+	if params == nil {
+		params = &ListUsagesQueryParams{}
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointSubscriptionComponentsUsagesList],
+		PathParams: &map[string]string{
+			"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+			"componentID":    fmt.Sprintf("%d", componentID),
+		},
+		QueryParams: params.toMap(),
+	}
+
+	data := []Usage{}
+
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return data, err
+	}
+	apiBody, bodyOK := ret.Body.([]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	// the result is an array of objects that have a usage key
+	for i := range apiBody {
+		if raw, rawOK := apiBody[i].(map[string]interface{}); rawOK {
+			usage, err := decodeUsage(raw["usage"])
+			if err == nil {
+				data = append(data, *usage)
+			}
+		}
+	}
+	return data, nil
+}
+
+// decodeUsage decodes a single usage. Chargify sends fractional quantities as strings and whole quantities as numbers,
+// so the input is weakly typed.
+func decodeUsage(raw interface{}) (*Usage, error) {
+	usage := &Usage{}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           usage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = decoder.Decode(raw)
+	return usage, err
+}
+
+// UsageRecorderOptions configures a UsageRecorder. Zero values use the defaults.
+type UsageRecorderOptions struct {
+	FlushInterval time.Duration                                                        // How often pending usage is sent. Defaults to one minute.
+	MaxPending    int                                                                  // Flush early once this many subscription and component pairs are pending. Defaults to 100.
+	MaxRetries    int                                                                  // How many times a failed send is retried during a flush. Defaults to 3; -1 turns off retries.
+	RetryDelay    time.Duration                                                        // The delay before the first retry, which doubles with each attempt. Defaults to one second.
+	Memo          string                                                               // (Optional) The memo sent with each usage
+	OnError       func(subscriptionID, componentID int64, quantity float64, err error) // (Optional) Called when a send fails after all retries, or is rejected
+}
+
+type usageKey struct {
+	subscriptionID int64
+	componentID    int64
+}
+
+// UsageRecorder buffers metered usage in memory, adding together the quantities for each subscription and component, and
+// records it with Chargify in the background. Usage that still fails after the retries is kept and sent with the next flush,
+// except usage Chargify rejects as invalid or for a subscription or component it cannot find, which is reported through
+// OnError and dropped. Close must be called to send any remaining usage and stop the background flushing.
+//
+// Delivery is at least once: when a send times out or fails with a server error, Chargify may still have recorded the
+// usage, and the retry or the next flush records it again. Set MaxRetries to -1 to turn off retries within a flush, and
+// use OnError to reconcile if double counting matters more than losing usage.
+type UsageRecorder struct {
+	options UsageRecorderOptions
+	send    func(subscriptionID, componentID int64, quantity float64, memo string) error
+
+	mutex   sync.Mutex
+	pending map[usageKey]float64
+	closed  bool
+
+	flushMutex sync.Mutex
+	flushNow   chan struct{}
+	done       chan struct{}
+	wait       sync.WaitGroup
+}
+
+// NewUsageRecorder creates a UsageRecorder and starts its background flushing. The options may be nil to use the defaults.
+func NewUsageRecorder(options *UsageRecorderOptions) *UsageRecorder {
+	return newUsageRecorder(options, func(subscriptionID, componentID int64, quantity float64, memo string) error {
+		_, err := RecordUsage(subscriptionID, componentID, quantity, memo)
+		return err
+	})
+}
+
+func newUsageRecorder(options *UsageRecorderOptions, send func(subscriptionID, componentID int64, quantity float64, memo string) error) *UsageRecorder {
+	recorder := &UsageRecorder{
+		send:     send,
+		pending:  map[usageKey]float64{},
+		flushNow: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	if options != nil {
+		recorder.options = *options
+	}
+	if recorder.options.FlushInterval <= 0 {
+		recorder.options.FlushInterval = time.Minute
+	}
+	if recorder.options.MaxPending <= 0 {
+		recorder.options.MaxPending = 100
+	}
+	if recorder.options.MaxRetries < 0 {
+		recorder.options.MaxRetries = 0
+	} else if recorder.options.MaxRetries == 0 {
+		recorder.options.MaxRetries = 3
+	}
+	if recorder.options.RetryDelay <= 0 {
+		recorder.options.RetryDelay = time.Second
+	}
+	recorder.wait.Add(1)
+	go recorder.run()
+	return recorder
+}
+
+func (recorder *UsageRecorder) run() {
+	defer recorder.wait.Done()
+	ticker := time.NewTicker(recorder.options.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			recorder.Flush()
+		case <-recorder.flushNow:
+			recorder.Flush()
+		case <-recorder.done:
+			return
+		}
+	}
+}
+
+// Add buffers usage for a component on a subscription. It returns an error if the recorder has been closed.
+func (recorder *UsageRecorder) Add(subscriptionID int64, componentID int64, quantity float64) error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	if recorder.closed {
+		return errors.New("usage recorder is closed")
+	}
+	recorder.pending[usageKey{subscriptionID, componentID}] += quantity
+	if len(recorder.pending) >= recorder.options.MaxPending {
+		select {
+		case recorder.flushNow <- struct{}{}:
+		default:
+			// a flush is already requested
+		}
+	}
+	return nil
+}
+
+// Pending returns the buffered quantity for a component on a subscription that has not been sent yet
+func (recorder *UsageRecorder) Pending(subscriptionID int64, componentID int64) float64 {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return recorder.pending[usageKey{subscriptionID, componentID}]
+}
+
+// Flush sends all of the pending usage now. Usage that fails after the retries is returned to the buffer, unless Chargify
+// rejected it, in which case it is dropped. An error describing the failures is returned.
+func (recorder *UsageRecorder) Flush() error {
+	return recorder.flush(true)
+}
+
+// flush sends the pending usage, returning usage that failed for a reason that may pass on a later attempt to the buffer
+// when requeue is true
+func (recorder *UsageRecorder) flush(requeue bool) error {
+	recorder.flushMutex.Lock()
+	defer recorder.flushMutex.Unlock()
+
+	recorder.mutex.Lock()
+	batch := recorder.pending
+	recorder.pending = map[usageKey]float64{}
+	recorder.mutex.Unlock()
+
+	failed := 0
+	var lastErr error
+	for key, quantity := range batch {
+		if quantity == 0 {
+			continue
+		}
+		err := recorder.sendWithRetries(key, quantity)
+		if err == nil {
+			continue
+		}
+		failed++
+		lastErr = err
+		if recorder.options.OnError != nil {
+			recorder.options.OnError(key.subscriptionID, key.componentID, quantity, err)
+		}
+		if !requeue || isRejectedUsage(err) {
+			continue
+		}
+		recorder.mutex.Lock()
+		recorder.pending[key] += quantity
+		recorder.mutex.Unlock()
+	}
+	if failed > 0 {
+		return fmt.Errorf("could not record %d usage entries: %w", failed, lastErr)
+	}
+	return nil
+}
+
+func (recorder *UsageRecorder) sendWithRetries(key usageKey, quantity float64) error {
+	delay := recorder.options.RetryDelay
+	err := recorder.send(key.subscriptionID, key.componentID, quantity, recorder.options.Memo)
+	for attempt := 0; err != nil && attempt < recorder.options.MaxRetries; attempt++ {
+		if isRejectedUsage(err) {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+		err = recorder.send(key.subscriptionID, key.componentID, quantity, recorder.options.Memo)
+	}
+	return err
+}
+
+// isRejectedUsage returns true if Chargify rejected the usage, so sending it again will not succeed
+func isRejectedUsage(err error) bool {
+	validationErr := &ValidationError{}
+	return errors.As(err, &validationErr) || errors.Is(err, ErrNotFound)
+}
+
+// Close stops the background flushing and sends any remaining usage. Usage that cannot be sent is reported in the error
+// and through OnError, and is discarded, so nothing is left pending.
+func (recorder *UsageRecorder) Close() error {
+	recorder.mutex.Lock()
+	if recorder.closed {
+		recorder.mutex.Unlock()
+		return nil
+	}
+	recorder.closed = true
+	recorder.mutex.Unlock()
+
+	close(recorder.done)
+	recorder.wait.Wait()
+	return recorder.flush(false)
+}
@@ -0,0 +1,148 @@
+package chargify
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type usageSent struct {
+	subscriptionID int64
+	componentID    int64
+	quantity       float64
+	memo           string
+}
+
+type fakeUsageSender struct {
+	mutex    sync.Mutex
+	sent     []usageSent
+	failures int
+	err      error
+}
+
+func (sender *fakeUsageSender) send(subscriptionID, componentID int64, quantity float64, memo string) error {
+	sender.mutex.Lock()
+	defer sender.mutex.Unlock()
+	if sender.failures > 0 {
+		sender.failures--
+		return sender.err
+	}
+	sender.sent = append(sender.sent, usageSent{subscriptionID, componentID, quantity, memo})
+	return nil
+}
+
+func (sender *fakeUsageSender) total() int {
+	sender.mutex.Lock()
+	defer sender.mutex.Unlock()
+	return len(sender.sent)
+}
+
+func TestUsageRecorderAggregatesAndFlushesOnClose(t *testing.T) {
+	sender := &fakeUsageSender{}
+	recorder := newUsageRecorder(&UsageRecorderOptions{FlushInterval: time.Hour, Memo: "batched"}, sender.send)
+	require.Nil(t, recorder.Add(1, 10, 2))
+	require.Nil(t, recorder.Add(1, 10, 3.5))
+	require.Nil(t, recorder.Add(2, 10, 1))
+	assert.Equal(t, 5.5, recorder.Pending(1, 10))
+	assert.Equal(t, 0, sender.total())
+
+	require.Nil(t, recorder.Close())
+	require.Equal(t, 2, sender.total())
+	for _, sent := range sender.sent {
+		assert.Equal(t, "batched", sent.memo)
+		if sent.subscriptionID == 1 {
+			assert.Equal(t, 5.5, sent.quantity)
+		} else {
+			assert.Equal(t, float64(1), sent.quantity)
+		}
+	}
+
+	assert.NotNil(t, recorder.Add(1, 10, 1))
+	assert.Nil(t, recorder.Close())
+}
+
+func TestUsageRecorderFlushesAtMaxPending(t *testing.T) {
+	sender := &fakeUsageSender{}
+	recorder := newUsageRecorder(&UsageRecorderOptions{FlushInterval: time.Hour, MaxPending: 2}, sender.send)
+	defer recorder.Close()
+	require.Nil(t, recorder.Add(1, 10, 1))
+	require.Nil(t, recorder.Add(1, 11, 1))
+	assert.Eventually(t, func() bool { return sender.total() == 2 }, time.Second, 5*time.Millisecond)
+}
+
+func TestUsageRecorderFlushesOnInterval(t *testing.T) {
+	sender := &fakeUsageSender{}
+	recorder := newUsageRecorder(&UsageRecorderOptions{FlushInterval: 10 * time.Millisecond}, sender.send)
+	defer recorder.Close()
+	require.Nil(t, recorder.Add(1, 10, 1))
+	assert.Eventually(t, func() bool { return sender.total() == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestUsageRecorderRetries(t *testing.T) {
+	// the send succeeds on the last retry
+	sender := &fakeUsageSender{failures: 2, err: errors.New("unavailable")}
+	recorder := newUsageRecorder(&UsageRecorderOptions{FlushInterval: time.Hour, MaxRetries: 2, RetryDelay: time.Millisecond}, sender.send)
+	require.Nil(t, recorder.Add(1, 10, 4))
+	require.Nil(t, recorder.Flush())
+	assert.Equal(t, 1, sender.total())
+
+	// the send keeps failing, so the usage is returned to the buffer and reported
+	reported := 0
+	sender.failures = 10
+	recorder.options.OnError = func(subscriptionID, componentID int64, quantity float64, err error) {
+		reported++
+	}
+	require.Nil(t, recorder.Add(1, 10, 4))
+	assert.NotNil(t, recorder.Flush())
+	assert.Equal(t, 1, reported)
+	assert.Equal(t, float64(4), recorder.Pending(1, 10))
+
+	// the usage is sent again on the next flush
+	sender.failures = 0
+	require.Nil(t, recorder.Flush())
+	assert.Equal(t, 2, sender.total())
+
+	// rejected usage is not retried, and is reported once and dropped rather than sent on every flush
+	for _, rejection := range []error{&ValidationError{Errors: []string{"Quantity is invalid"}}, ErrNotFound} {
+		reported = 0
+		sender.failures = 2
+		sender.err = rejection
+		require.Nil(t, recorder.Add(1, 10, 4))
+		assert.NotNil(t, recorder.Flush())
+		assert.Equal(t, 1, sender.failures)
+		assert.Equal(t, 1, reported)
+		assert.Zero(t, recorder.Pending(1, 10))
+		require.Nil(t, recorder.Flush())
+		assert.Equal(t, 1, reported)
+	}
+
+	// usage that cannot be sent on close is reported and discarded
+	reported = 0
+	sender.failures = 10
+	sender.err = errors.New("unavailable")
+	require.Nil(t, recorder.Add(1, 10, 4))
+	assert.NotNil(t, recorder.Close())
+	assert.Equal(t, 1, reported)
+	assert.Zero(t, recorder.Pending(1, 10))
+	assert.Equal(t, 2, sender.total())
+}
+
+func TestDecodeUsage(t *testing.T) {
+	usage, err := decodeUsage(map[string]interface{}{
+		"id":           float64(1),
+		"quantity":     "2.5",
+		"memo":         "api calls",
+		"component_id": float64(3),
+	})
+	require.Nil(t, err)
+	assert.Equal(t, 2.5, usage.Quantity)
+	assert.Equal(t, int64(3), usage.ComponentID)
+
+	usage, err = decodeUsage(map[string]interface{}{"quantity": float64(7)})
+	require.Nil(t, err)
+	assert.Equal(t, float64(7), usage.Quantity)
+}
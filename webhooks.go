@@ -0,0 +1,264 @@
+package chargify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// WebhookEvent represents one of the event keys that a webhook endpoint can subscribe to
+type WebhookEvent string
+
+var (
+	// WebhookEventSignupSuccess is sent when a subscription is successfully created
+	WebhookEventSignupSuccess WebhookEvent = "signup_success"
+	// WebhookEventSignupFailure is sent when a subscription could not be created
+	WebhookEventSignupFailure WebhookEvent = "signup_failure"
+	// WebhookEventRenewalSuccess is sent when a subscription renews successfully
+	WebhookEventRenewalSuccess WebhookEvent = "renewal_success"
+	// WebhookEventRenewalFailure is sent when a subscription renewal fails
+	WebhookEventRenewalFailure WebhookEvent = "renewal_failure"
+	// WebhookEventPaymentSuccess is sent when a payment is successfully captured
+	WebhookEventPaymentSuccess WebhookEvent = "payment_success"
+	// WebhookEventPaymentFailure is sent when a payment fails
+	WebhookEventPaymentFailure WebhookEvent = "payment_failure"
+	// WebhookEventBillingDateChange is sent when the billing date of a subscription changes
+	WebhookEventBillingDateChange WebhookEvent = "billing_date_change"
+	// WebhookEventSubscriptionStateChange is sent when the state of a subscription changes
+	WebhookEventSubscriptionStateChange WebhookEvent = "subscription_state_change"
+	// WebhookEventSubscriptionProductChange is sent when the product of a subscription changes
+	WebhookEventSubscriptionProductChange WebhookEvent = "subscription_product_change"
+	// WebhookEventSubscriptionCardUpdate is sent when the card on a subscription is updated
+	WebhookEventSubscriptionCardUpdate WebhookEvent = "subscription_card_update"
+	// WebhookEventExpiringCard is sent when the card on a subscription is about to expire
+	WebhookEventExpiringCard WebhookEvent = "expiring_card"
+	// WebhookEventCustomerCreate is sent when a customer is created
+	WebhookEventCustomerCreate WebhookEvent = "customer_create"
+	// WebhookEventCustomerUpdate is sent when a customer is updated
+	WebhookEventCustomerUpdate WebhookEvent = "customer_update"
+	// WebhookEventCustomerDelete is sent when a customer is deleted
+	WebhookEventCustomerDelete WebhookEvent = "customer_delete"
+	// WebhookEventComponentAllocationChange is sent when a component allocation changes
+	WebhookEventComponentAllocationChange WebhookEvent = "component_allocation_change"
+	// WebhookEventMeteredUsage is sent when metered usage is recorded
+	WebhookEventMeteredUsage WebhookEvent = "metered_usage"
+	// WebhookEventDunningStepReached is sent when a subscription reaches a new dunning step
+	WebhookEventDunningStepReached WebhookEvent = "dunning_step_reached"
+	// WebhookEventInvoiceIssued is sent when an invoice is issued
+	WebhookEventInvoiceIssued WebhookEvent = "invoice_issued"
+	// WebhookEventRefundSuccess is sent when a refund is successful
+	WebhookEventRefundSuccess WebhookEvent = "refund_success"
+)
+
+// WebhookEndpoint represents a URL that Chargify will send webhooks to, along with the events it is subscribed to
+type WebhookEndpoint struct {
+	ID                   int64          `json:"id,omitempty" mapstructure:"id"`                             // The endpoint ID in Chargify
+	URL                  string         `json:"url" mapstructure:"url"`                                     // The URL webhooks will be sent to
+	SiteID               int64          `json:"site_id,omitempty" mapstructure:"site_id"`                   // The site the endpoint belongs to
+	Status               string         `json:"status,omitempty" mapstructure:"status"`                     // Whether the endpoint is enabled or disabled
+	WebhookSubscriptions []WebhookEvent `json:"webhook_subscriptions" mapstructure:"webhook_subscriptions"` // The events the endpoint is subscribed to
+}
+
+// Webhook represents a single webhook that was (or will be) sent by Chargify
+type Webhook struct {
+	ID                  int64  `json:"id" mapstructure:"id"`
+	Event               string `json:"event" mapstructure:"event"`                                   // The event key of the webhook, such as payment_success
+	CreatedAt           string `json:"created_at" mapstructure:"created_at"`                         // Timestamp indicating when the webhook was created
+	LastError           string `json:"last_error" mapstructure:"last_error"`                         // The text of the last error encountered when sending the webhook, if any
+	LastErrorAt         string `json:"last_error_at" mapstructure:"last_error_at"`                   // Timestamp of the last error
+	AcceptedAt          string `json:"accepted_at" mapstructure:"accepted_at"`                       // Timestamp indicating when the webhook was accepted by your endpoint
+	LastSentAt          string `json:"last_sent_at" mapstructure:"last_sent_at"`                     // Timestamp indicating when the webhook was last sent
+	LastSentURL         string `json:"last_sent_url" mapstructure:"last_sent_url"`                   // The URL the webhook was last sent to
+	Successful          bool   `json:"successful" mapstructure:"successful"`                         // Whether or not the webhook was successfully delivered
+	Body                string `json:"body" mapstructure:"body"`                                     // The body of the webhook
+	Signature           string `json:"signature" mapstructure:"signature"`                           // The signature of the webhook
+	SignatureHMACSHA256 string `json:"signature_hmac_sha_256" mapstructure:"signature_hmac_sha_256"` // The HMAC-SHA256 signature of the webhook
+}
+
+// ListWebhooksQueryParams are the query parameters for listing webhooks. Status may be one of successful, failed, pending,
+// or paused. Order may be newest_first or oldest_first.
+type ListWebhooksQueryParams struct {
+	Status       *string `json:"status"`
+	SinceDate    *string `json:"since_date"`
+	UntilDate    *string `json:"until_date"`
+	Page         *int    `json:"page"`
+	PerPage      *int    `json:"per_page"`
+	Order        *string `json:"order"`
+	Subscription *int64  `json:"subscription"`
+}
+
+func (input *ListWebhooksQueryParams) toMap() *map[string]string {
+	m := map[string]string{}
+	if input.Status != nil {
+		m["status"] = ToString(input.Status)
+	}
+	if input.SinceDate != nil {
+		m["since_date"] = ToString(input.SinceDate)
+	}
+	if input.UntilDate != nil {
+		m["until_date"] = ToString(input.UntilDate)
+	}
+	if input.Page != nil {
+		m["page"] = fmt.Sprintf("%d", ToInt(input.Page))
+	}
+	if input.PerPage != nil {
+		m["per_page"] = fmt.Sprintf("%d", ToInt(input.PerPage))
+	}
+	if input.Order != nil {
+		m["order"] = ToString(input.Order)
+	}
+	if input.Subscription != nil {
+		m["subscription"] = fmt.Sprintf("%d", ToInt64(input.Subscription))
+	}
+	return &m
+}
+
+// ListWebhooks lists the webhooks sent by the site based upon the passed in query params
+func ListWebhooks(params *ListWebhooksQueryParams) ([]Webhook, error) {
+	if params == nil {
+		params = &ListWebhooksQueryParams{}
+	}
+	options := &makeCallOptions{
+		End:         endpoints[endpointWebhooksList],
+		QueryParams: params.toMap(),
+	}
+
+	data := []Webhook{}
+
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return data, err
+	}
+	apiBody, bodyOK := ret.Body.([]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	// the result is an array of objects that have a webhook key
+	for i := range apiBody {
+		if raw, rawOK := apiBody[i].(map[string]interface{}); rawOK {
+			webhook := Webhook{}
+			err = mapstructure.Decode(raw["webhook"], &webhook)
+			if err == nil {
+				data = append(data, webhook)
+			}
+		}
+	}
+	return data, nil
+}
+
+// ReplayWebhooks asks Chargify to resend the webhooks with the passed in ids, such as ones that previously failed
+func ReplayWebhooks(webhookIDs []int64) error {
+	if len(webhookIDs) == 0 {
+		return errors.New("at least one webhook id is required")
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointWebhooksReplay],
+		Body: map[string][]int64{
+			"ids": webhookIDs,
+		},
+	}
+	_, err := makeAPICall(options)
+	return err
+}
+
+// EnableWebhooks enables or disables the sending of webhooks for the entire site
+func EnableWebhooks(enabled bool) error {
+	options := &makeCallOptions{
+		End: endpoints[endpointWebhooksEnable],
+		Body: map[string]bool{
+			"webhooks_enabled": enabled,
+		},
+	}
+	_, err := makeAPICall(options)
+	return err
+}
+
+// ListWebhookEndpoints lists the webhook endpoints configured for the site
+func ListWebhookEndpoints() ([]WebhookEndpoint, error) {
+	options := &makeCallOptions{
+		End: endpoints[endpointWebhookEndpointsList],
+	}
+
+	data := []WebhookEndpoint{}
+
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return data, err
+	}
+	apiBody, bodyOK := ret.Body.([]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	// the result is an array of objects that have an endpoint key
+	for i := range apiBody {
+		if raw, rawOK := apiBody[i].(map[string]interface{}); rawOK {
+			endpoint := WebhookEndpoint{}
+			err = mapstructure.Decode(raw["endpoint"], &endpoint)
+			if err == nil {
+				data = append(data, endpoint)
+			}
+		}
+	}
+	return data, nil
+}
+
+// CreateWebhookEndpoint creates a new webhook endpoint subscribed to the passed in events
+func CreateWebhookEndpoint(url string, events []WebhookEvent) (*WebhookEndpoint, error) {
+	if url == "" {
+		return nil, errors.New("url is required")
+	}
+	input := &WebhookEndpoint{
+		URL:                  url,
+		WebhookSubscriptions: events,
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointWebhookEndpointCreate],
+		Body: map[string]WebhookEndpoint{
+			"endpoint": *input,
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return nil, err
+	}
+	// if successful, the endpoint should come back in a map[endpoint]WebhookEndpoint format
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	err = mapstructure.Decode(apiBody["endpoint"], input)
+	return input, err
+}
+
+// UpdateWebhookEndpoint updates the URL and subscribed events of an existing webhook endpoint. The result is placed in the input.
+// Passing in an empty list of events effectively stops the endpoint from receiving webhooks.
+func UpdateWebhookEndpoint(input *WebhookEndpoint) error {
+	if input.ID == 0 || input.URL == "" {
+		return errors.New("id and url are required")
+	}
+	if input.WebhookSubscriptions == nil {
+		input.WebhookSubscriptions = []WebhookEvent{}
+	}
+	options := &makeCallOptions{
+		End: endpoints[endpointWebhookEndpointUpdate],
+		PathParams: &map[string]string{
+			"endpointID": fmt.Sprintf("%d", input.ID),
+		},
+		Body: map[string]map[string]interface{}{
+			"endpoint": {
+				"url":                   input.URL,
+				"webhook_subscriptions": input.WebhookSubscriptions,
+			},
+		},
+	}
+	ret, err := makeAPICall(options)
+	if err != nil {
+		return err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return errors.New("could not understand server response")
+	}
+	return mapstructure.Decode(apiBody["endpoint"], input)
+}
@@ -0,0 +1,50 @@
+package chargify
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListWebhooksParams(t *testing.T) {
+	params := ListWebhooksQueryParams{
+		Status:       FromString("failed"),
+		PerPage:      FromInt(50),
+		Subscription: FromInt64(12345),
+	}
+	m := *params.toMap()
+	assert.Equal(t, "failed", m["status"])
+	assert.Equal(t, "50", m["per_page"])
+	assert.Equal(t, "12345", m["subscription"])
+	_, ok := m["page"]
+	assert.False(t, ok)
+}
+
+func TestWebhookEndpointCRU(t *testing.T) {
+	customID := rand.Int63n(999999999)
+	url := fmt.Sprintf("https://example.com/webhooks/%d", customID)
+
+	endpoint, err := CreateWebhookEndpoint(url, []WebhookEvent{WebhookEventPaymentSuccess})
+	require.Nil(t, err)
+	require.NotNil(t, endpoint)
+	assert.NotZero(t, endpoint.ID)
+	assert.Equal(t, url, endpoint.URL)
+
+	found, err := ListWebhookEndpoints()
+	assert.Nil(t, err)
+	assert.NotZero(t, len(found))
+
+	endpoint.WebhookSubscriptions = append(endpoint.WebhookSubscriptions, WebhookEventPaymentFailure)
+	err = UpdateWebhookEndpoint(endpoint)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(endpoint.WebhookSubscriptions))
+
+	webhooks, err := ListWebhooks(&ListWebhooksQueryParams{
+		Status: FromString("failed"),
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, webhooks)
+}